@@ -1,15 +1,19 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+	_ "time/tzdata" // база часовых поясов на случай, если в системе её нет
 
 	"event-planner-bot/config"
 	"event-planner-bot/internal/auth"
 	"event-planner-bot/internal/bot"
 	"event-planner-bot/internal/database"
+	"event-planner-bot/internal/scheduler"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -36,9 +40,6 @@ func main() {
 
 	log.Printf("База данных: %s", cfg.DBPath)
 
-	// Создаем сервис аутентификации
-	authService := auth.NewAuthService(repo)
-
 	// Создаем бота
 	botAPI, err := tgbotapi.NewBotAPI(cfg.TelegramToken)
 	if err != nil {
@@ -48,8 +49,31 @@ func main() {
 	botAPI.Debug = cfg.Debug
 	log.Printf("Авторизован как %s", botAPI.Self.UserName)
 
+	// Создаем сервис аутентификации; администраторов групп он узнаёт у Telegram
+	authService := auth.NewAuthService(repo, cfg.AdminIDs, bot.ChatAdministrators(botAPI))
+	if len(cfg.AdminIDs) == 0 {
+		log.Println("ADMIN_TELEGRAM_ID не установлен, админы не назначены")
+	}
+	if err := authService.BootstrapAdmins(); err != nil {
+		log.Fatalf("Ошибка назначения админов: %v", err)
+	}
+
 	// Создаем обработчик
-	botHandler := bot.NewBotHandler(botAPI, repo, authService, cfg.AdminID)
+	botHandler := bot.NewBotHandler(botAPI, repo, authService, cfg)
+
+	// Фоновые задачи
+	ctx, cancel := context.WithCancel(context.Background())
+	sched := scheduler.New()
+	sched.Every("reminders", time.Minute, botHandler.SendReminders)
+	sched.Every("event-statuses", time.Minute, botHandler.UpdateEventStatuses)
+	sched.Every("digests", 15*time.Minute, botHandler.SendDigests)
+	sched.Every("sessions-cleanup", time.Hour, func(now time.Time) {
+		if _, err := repo.DeleteExpiredSessions(now); err != nil {
+			log.Printf("Ошибка очистки диалогов: %v", err)
+		}
+	})
+	sched.Start(ctx)
+	log.Printf("Напоминания за: %v", cfg.ReminderOffsets)
 
 	// Настраиваем обновления
 	u := tgbotapi.NewUpdate(0)
@@ -71,6 +95,8 @@ func main() {
 		case <-stopChan:
 			log.Println("Остановка бота...")
 			botAPI.StopReceivingUpdates()
+			cancel()
+			sched.Wait()
 			return
 		}
 	}
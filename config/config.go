@@ -1,6 +1,12 @@
 package config
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // getEnv получает переменную окружения или значение по умолчанию
 func getEnv(key, defaultValue string) string {
@@ -11,18 +17,74 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+// parseIDList разбирает список Telegram ID через запятую
+func parseIDList(value string) ([]int64, error) {
+	var ids []int64
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("неверный Telegram ID %q: %w", part, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseDurationList разбирает список интервалов через запятую, например "24h,1h"
+func parseDurationList(value string) ([]time.Duration, error) {
+	var durations []time.Duration
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		d, err := time.ParseDuration(part)
+		if err != nil {
+			return nil, err
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("интервал должен быть положительным: %q", part)
+		}
+		durations = append(durations, d)
+	}
+	return durations, nil
+}
+
 type Config struct {
-	TelegramToken string
-	DBPath        string
-	AdminID       int64
-	Debug         bool
+	TelegramToken   string
+	DBPath          string
+	AdminIDs        []int64
+	ReminderOffsets []time.Duration
+	DefaultTimezone *time.Location // для пользователей, не выбравших свой пояс
+	Debug           bool
 }
 
 func LoadConfig() (*Config, error) {
+	adminIDs, err := parseIDList(getEnv("ADMIN_TELEGRAM_ID", ""))
+	if err != nil {
+		return nil, fmt.Errorf("ADMIN_TELEGRAM_ID: %w", err)
+	}
+
+	reminderOffsets, err := parseDurationList(getEnv("REMINDER_OFFSETS", "24h,1h"))
+	if err != nil {
+		return nil, fmt.Errorf("REMINDER_OFFSETS: %w", err)
+	}
+
+	defaultTimezone, err := time.LoadLocation(getEnv("DEFAULT_TIMEZONE", "Europe/Moscow"))
+	if err != nil {
+		return nil, fmt.Errorf("DEFAULT_TIMEZONE: %w", err)
+	}
+
 	return &Config{
-		TelegramToken: getEnv("TELEGRAM_BOT_TOKEN", "8250977349:AAHPQwyMLuhH5obsa8r59xLoiuxjOLbI8gw"),
-		DBPath:        getEnv("DB_PATH", "./data/events.db"),
-		AdminID:       2025081326,
-		Debug:         getEnv("DEBUG", "true") == "true",
+		TelegramToken:   getEnv("TELEGRAM_BOT_TOKEN", "8250977349:AAHPQwyMLuhH5obsa8r59xLoiuxjOLbI8gw"),
+		DBPath:          getEnv("DB_PATH", "./data/events.db"),
+		AdminIDs:        adminIDs,
+		ReminderOffsets: reminderOffsets,
+		DefaultTimezone: defaultTimezone,
+		Debug:           getEnv("DEBUG", "true") == "true",
 	}, nil
 }
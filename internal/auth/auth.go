@@ -1,18 +1,58 @@
 package auth
 
 import (
+	"errors"
 	"log"
+	"time"
 
 	"event-planner-bot/internal/database"
 	"event-planner-bot/internal/models"
 )
 
+// Действие доступно только админам
+var ErrNotAdmin = errors.New("нет прав администратора")
+
+// Админа из конфигурации нельзя разжаловать командой: при следующем
+// входе он снова получил бы права
+var ErrConfiguredAdmin = errors.New("админ указан в конфигурации")
+
 type AuthService struct {
-	repo *database.Storage // Вместо *database.Repository
+	repo        *database.Storage // Вместо *database.Repository
+	adminIDs    map[int64]bool    // админы из конфигурации
+	chatAdmins  ChatAdminsFunc    // администраторы групп из Telegram
+	adminsCache *chatAdminsCache
+}
+
+func NewAuthService(repo *database.Storage, adminIDs []int64, chatAdmins ChatAdminsFunc) *AuthService {
+	ids := make(map[int64]bool, len(adminIDs))
+	for _, id := range adminIDs {
+		ids[id] = true
+	}
+	return &AuthService{
+		repo:        repo,
+		adminIDs:    ids,
+		chatAdmins:  chatAdmins,
+		adminsCache: newChatAdminsCache(),
+	}
 }
 
-func NewAuthService(repo *database.Storage) *AuthService {
-	return &AuthService{repo: repo}
+// Назначение админами уже известных пользователей из конфигурации
+func (a *AuthService) BootstrapAdmins() error {
+	for telegramID := range a.adminIDs {
+		user, err := a.repo.GetUserByTelegramID(telegramID)
+		if err != nil {
+			return err
+		}
+		// Незнакомые пользователи станут админами при первом входе
+		if user == nil || user.IsAdmin {
+			continue
+		}
+		if err := a.repo.PromoteAdmin(telegramID); err != nil {
+			return err
+		}
+		log.Printf("Пользователь %d назначен админом из конфигурации", telegramID)
+	}
+	return nil
 }
 
 // Регистрация/логин пользователя Telegram
@@ -30,7 +70,7 @@ func (a *AuthService) AuthenticateTelegramUser(telegramID int64, username, first
 			Username:   username,
 			Name:       firstName,
 			Surname:    lastName,
-			IsAdmin:    false, // По умолчанию не админ
+			IsAdmin:    a.adminIDs[telegramID], // Админ, только если указан в конфигурации
 		}
 
 		if err := a.repo.CreateUser(user); err != nil {
@@ -41,6 +81,14 @@ func (a *AuthService) AuthenticateTelegramUser(telegramID int64, username, first
 	} else {
 		// Обновляем информацию, если нужно
 		log.Printf("Пользователь авторизован: %s (ID: %d)", username, telegramID)
+
+		if a.adminIDs[telegramID] && !user.IsAdmin {
+			if err := a.repo.PromoteAdmin(telegramID); err != nil {
+				return nil, err
+			}
+			user.IsAdmin = true
+			log.Printf("Пользователь %d назначен админом из конфигурации", telegramID)
+		}
 	}
 
 	return user, nil
@@ -55,16 +103,65 @@ func (a *AuthService) IsAdmin(telegramID int64) (bool, error) {
 	return user.IsAdmin, nil
 }
 
+// Является ли пользователь администратором группы chatID в Telegram.
+// Глобальные права админа бота здесь не учитываются; в личных чатах
+// администраторов нет
+func (a *AuthService) IsChatAdmin(chatID, telegramID int64) (bool, error) {
+	if chatID >= 0 || a.chatAdmins == nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	ids, ok := a.adminsCache.get(chatID, now)
+	if !ok {
+		userIDs, err := a.chatAdmins(chatID)
+		if err != nil {
+			return false, err
+		}
+		ids = a.adminsCache.put(chatID, userIDs, now)
+	}
+	return ids[telegramID], nil
+}
+
 // Назначение админа (только для существующих админов)
-func (a *AuthService) MakeAdmin(telegramID int64) error {
-	// В реальном приложении здесь была бы сложная логика
-	// Для простоты: если пользователь существует, делаем его админом
-	user, err := a.repo.GetUserByTelegramID(telegramID)
-	if err != nil || user == nil {
+func (a *AuthService) MakeAdmin(callerID, telegramID int64) error {
+	if err := a.requireAdmin(callerID); err != nil {
 		return err
 	}
 
-	// В реальности здесь должен быть SQL UPDATE
-	user.IsAdmin = true
+	if err := a.repo.PromoteAdmin(telegramID); err != nil {
+		return err
+	}
+
+	log.Printf("Пользователь %d назначил админом %d", callerID, telegramID)
+	return nil
+}
+
+// Снятие прав админа (только для существующих админов).
+// Админов из конфигурации разжаловать нельзя
+func (a *AuthService) RevokeAdmin(callerID, telegramID int64) error {
+	if err := a.requireAdmin(callerID); err != nil {
+		return err
+	}
+	if a.adminIDs[telegramID] {
+		return ErrConfiguredAdmin
+	}
+
+	if err := a.repo.DemoteAdmin(telegramID); err != nil {
+		return err
+	}
+
+	log.Printf("Пользователь %d снял права админа с %d", callerID, telegramID)
+	return nil
+}
+
+func (a *AuthService) requireAdmin(telegramID int64) error {
+	isAdmin, err := a.IsAdmin(telegramID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return ErrNotAdmin
+	}
 	return nil
 }
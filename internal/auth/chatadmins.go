@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// Сколько помнить список администраторов группы; за это время изменения
+// в группе могут не учитываться
+const chatAdminsTTL = 10 * time.Minute
+
+// Загрузка Telegram ID администраторов группы (getChatAdministrators)
+type ChatAdminsFunc func(chatID int64) ([]int64, error)
+
+// Администраторы групп, загруженные из Telegram
+type chatAdminsCache struct {
+	mu    sync.Mutex
+	chats map[int64]chatAdmins
+}
+
+type chatAdmins struct {
+	ids       map[int64]bool
+	expiresAt time.Time
+}
+
+func newChatAdminsCache() *chatAdminsCache {
+	return &chatAdminsCache{chats: make(map[int64]chatAdmins)}
+}
+
+func (c *chatAdminsCache) get(chatID int64, now time.Time) (map[int64]bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	admins, ok := c.chats[chatID]
+	if !ok || now.After(admins.expiresAt) {
+		return nil, false
+	}
+	return admins.ids, true
+}
+
+func (c *chatAdminsCache) put(chatID int64, userIDs []int64, now time.Time) map[int64]bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Заодно выбрасываем устаревшие списки, чтобы кэш не рос
+	for id, admins := range c.chats {
+		if now.After(admins.expiresAt) {
+			delete(c.chats, id)
+		}
+	}
+
+	ids := make(map[int64]bool, len(userIDs))
+	for _, id := range userIDs {
+		ids[id] = true
+	}
+	c.chats[chatID] = chatAdmins{ids: ids, expiresAt: now.Add(chatAdminsTTL)}
+	return ids
+}
@@ -0,0 +1,246 @@
+package bot
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-planner-bot/internal/auth"
+	"event-planner-bot/internal/database"
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Сколько пользователей показывать на одной странице /admin_users
+const adminUsersPageSize = 20
+
+// Проверка прав админа с ответом пользователю
+func (h *BotHandler) requireAdmin(chatID int64, user *models.User) bool {
+	isAdmin, err := h.auth.IsAdmin(user.TelegramID)
+	if err != nil {
+		log.Printf("IsAdmin error: %v", err)
+	}
+	if err != nil || !isAdmin {
+		h.sendMessage(chatID, h.locale(user.TelegramID).T("not_admin"))
+		return false
+	}
+	return true
+}
+
+// Разбор числового аргумента команды
+func parseIDArgument(msg *tgbotapi.Message) (int64, bool) {
+	arg := strings.TrimSpace(msg.CommandArguments())
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// /admin_users [страница] - список пользователей
+func (h *BotHandler) handleAdminUsers(msg *tgbotapi.Message, user *models.User) {
+	chatID := msg.Chat.ID
+	if !h.requireAdmin(chatID, user) {
+		return
+	}
+	lc := h.locale(user.TelegramID)
+
+	page := 1
+	if arg := strings.TrimSpace(msg.CommandArguments()); arg != "" {
+		p, err := strconv.Atoi(arg)
+		if err != nil || p < 1 {
+			h.sendMessage(chatID, lc.T("admin_bad_page"))
+			return
+		}
+		page = p
+	}
+
+	total, err := h.repo.CountUsers()
+	if err != nil {
+		h.sendMessage(chatID, lc.T("admin_users_error"))
+		log.Printf("Count users error: %v", err)
+		return
+	}
+
+	pages := (total + adminUsersPageSize - 1) / adminUsersPageSize
+	if pages == 0 {
+		pages = 1
+	}
+	if page > pages {
+		h.sendMessage(chatID, render(lc, "admin_no_page", map[string]int{"Page": page, "Pages": pages}))
+		return
+	}
+
+	users, err := h.repo.GetUsers(adminUsersPageSize, (page-1)*adminUsersPageSize)
+	if err != nil {
+		h.sendMessage(chatID, lc.T("admin_users_error"))
+		log.Printf("Get users error: %v", err)
+		return
+	}
+
+	// В списке имя показывается вместе с фамилией
+	for i := range users {
+		users[i].Name = strings.TrimSpace(users[i].Name + " " + users[i].Surname)
+	}
+
+	h.sendMessage(chatID, render(lc, "admin_users", map[string]interface{}{
+		"Users": users,
+		"Page":  page,
+		"Pages": pages,
+		"Next":  page + 1,
+		"Total": total,
+	}))
+}
+
+// /admin_stats - статистика
+func (h *BotHandler) handleAdminStats(msg *tgbotapi.Message, user *models.User) {
+	chatID := msg.Chat.ID
+	if !h.requireAdmin(chatID, user) {
+		return
+	}
+	lc := h.locale(user.TelegramID)
+
+	stats, err := h.repo.GetStats(time.Now())
+	if err != nil {
+		h.sendMessage(chatID, lc.T("admin_stats_error"))
+		log.Printf("Get stats error: %v", err)
+		return
+	}
+
+	for i := range stats.Creators {
+		c := &stats.Creators[i]
+		if c.Username != "" {
+			c.Name = "@" + c.Username
+		}
+		if c.Name == "" {
+			c.Name = strconv.FormatInt(c.TelegramID, 10)
+		}
+	}
+
+	h.sendMessage(chatID, render(lc, "admin_stats", stats))
+}
+
+// /admin_makeadmin ID - назначить пользователя админом
+func (h *BotHandler) handleAdminMakeAdmin(msg *tgbotapi.Message, user *models.User) {
+	chatID := msg.Chat.ID
+	if !h.requireAdmin(chatID, user) {
+		return
+	}
+	lc := h.locale(user.TelegramID)
+
+	targetID, ok := parseIDArgument(msg)
+	if !ok {
+		h.sendMessage(chatID, lc.T("admin_makeadmin_usage"))
+		return
+	}
+
+	target, err := h.repo.GetUserByTelegramID(targetID)
+	if err != nil {
+		h.sendMessage(chatID, lc.T("user_search_error"))
+		log.Printf("Get user error: %v", err)
+		return
+	}
+	if target == nil {
+		h.sendMessage(chatID, lc.T("user_not_found"))
+		return
+	}
+	if target.IsAdmin {
+		h.sendMessage(chatID, lc.T("admin_already"))
+		return
+	}
+
+	if err := h.auth.MakeAdmin(user.TelegramID, targetID); err != nil {
+		h.sendMessage(chatID, adminErrorMessage(lc, err, "admin_makeadmin_error"))
+		log.Printf("Make admin error: %v", err)
+		return
+	}
+
+	h.sendMessage(chatID, render(lc, "admin_made", targetID))
+}
+
+// /admin_revoke ID - снять права админа
+func (h *BotHandler) handleAdminRevoke(msg *tgbotapi.Message, user *models.User) {
+	chatID := msg.Chat.ID
+	if !h.requireAdmin(chatID, user) {
+		return
+	}
+	lc := h.locale(user.TelegramID)
+
+	targetID, ok := parseIDArgument(msg)
+	if !ok {
+		h.sendMessage(chatID, lc.T("admin_revoke_usage"))
+		return
+	}
+
+	if err := h.auth.RevokeAdmin(user.TelegramID, targetID); err != nil {
+		h.sendMessage(chatID, adminErrorMessage(lc, err, "admin_revoke_error"))
+		log.Printf("Revoke admin error: %v", err)
+		return
+	}
+
+	h.sendMessage(chatID, render(lc, "admin_revoked", targetID))
+}
+
+// Текст ошибки для админских команд; fallback - ключ сообщения
+// для остальных ошибок
+func adminErrorMessage(lc *i18n.Locale, err error, fallback string) string {
+	switch {
+	case errors.Is(err, auth.ErrNotAdmin):
+		return lc.T("not_admin")
+	case errors.Is(err, auth.ErrConfiguredAdmin):
+		return lc.T("admin_configured")
+	case errors.Is(err, database.ErrUserNotFound):
+		return lc.T("user_not_found")
+	case errors.Is(err, database.ErrLastAdmin):
+		return lc.T("admin_last")
+	default:
+		return lc.T(fallback)
+	}
+}
+
+// /admin_delete_event ID - удалить мероприятие
+func (h *BotHandler) handleAdminDeleteEvent(msg *tgbotapi.Message, user *models.User) {
+	chatID := msg.Chat.ID
+	if !h.requireAdmin(chatID, user) {
+		return
+	}
+	lc := h.locale(user.TelegramID)
+
+	eventID, ok := parseIDArgument(msg)
+	if !ok {
+		h.sendMessage(chatID, lc.T("admin_delete_usage"))
+		return
+	}
+
+	event, err := h.repo.GetEventByID(eventID)
+	if err != nil {
+		h.sendMessage(chatID, lc.T("event_search_error"))
+		log.Printf("Get event error: %v", err)
+		return
+	}
+	if event == nil {
+		h.sendMessage(chatID, lc.T("event_not_found"))
+		return
+	}
+	// Мероприятия групп удаляют только их администраторы
+	if !h.canManageEvent(user, event) {
+		h.sendMessage(chatID, forbiddenText(lc, event, "delete_forbidden"))
+		return
+	}
+
+	subscribers := h.eventSubscribers(eventID)
+
+	if err := h.repo.DeleteEvent(eventID); err != nil {
+		h.sendMessage(chatID, lc.T("admin_delete_error"))
+		log.Printf("Delete event error: %v", err)
+		return
+	}
+
+	log.Printf("Админ %d удалил мероприятие %d", user.TelegramID, eventID)
+	h.notifyEventDeleted(event, subscribers, user.TelegramID)
+	h.sendMessage(chatID, render(lc, "event_deleted", event))
+}
@@ -0,0 +1,218 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Формат callback-data: "<версия>:<действие>:<аргументы через :>",
+// числа в base36. Telegram ограничивает callback-data 64 байтами
+const (
+	callbackVersion  = "1"
+	callbackMaxBytes = 64
+)
+
+// Действия кнопок
+const (
+	actionDetails       = "d"
+	actionJoin          = "j"
+	actionMaybe         = "m"
+	actionLeave         = "l"
+	actionEdit          = "e"
+	actionEditField     = "ef"
+	actionEditScope     = "es"
+	actionEditDone      = "ed"
+	actionDelete        = "x"
+	actionDeleteConfirm = "xy"
+	actionDismiss       = "n"
+	actionTimezone      = "tz"
+	actionICS           = "ic"
+	actionSearchPage    = "sp"
+	actionEventsPage    = "lp"
+	actionLanguage      = "lg"
+	actionSettings      = "st"
+)
+
+// Разобранные данные нажатой кнопки
+type callbackData struct {
+	Action string
+	Args   []int64
+}
+
+// Кодирование данных кнопки
+func encodeCallback(action string, args ...int64) string {
+	parts := make([]string, 0, len(args)+2)
+	parts = append(parts, callbackVersion, action)
+	for _, arg := range args {
+		parts = append(parts, strconv.FormatInt(arg, 36))
+	}
+
+	data := strings.Join(parts, ":")
+	if len(data) > callbackMaxBytes {
+		log.Printf("callback-data длиннее %d байт: %q", callbackMaxBytes, data)
+	}
+	return data
+}
+
+// Разбор данных кнопки
+func decodeCallback(data string) (*callbackData, error) {
+	parts := strings.Split(data, ":")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("неверный формат callback-data: %q", data)
+	}
+	if parts[0] != callbackVersion {
+		return nil, fmt.Errorf("неподдерживаемая версия callback-data: %q", parts[0])
+	}
+
+	cb := &callbackData{Action: parts[1]}
+	for _, part := range parts[2:] {
+		arg, err := strconv.ParseInt(part, 36, 64)
+		if err != nil {
+			return nil, fmt.Errorf("неверный аргумент callback-data %q: %w", part, err)
+		}
+		cb.Args = append(cb.Args, arg)
+	}
+	return cb, nil
+}
+
+// Аргумент кнопки по номеру
+func (cb *callbackData) arg(i int) (int64, bool) {
+	if i >= len(cb.Args) {
+		return 0, false
+	}
+	return cb.Args[i], true
+}
+
+// Обработка нажатия inline-кнопки
+func (h *BotHandler) handleCallback(cq *tgbotapi.CallbackQuery) {
+	// На каждое нажатие нужно ответить, иначе у кнопки крутятся часики
+	answer := ""
+	defer func() {
+		if _, err := h.bot.Request(tgbotapi.NewCallback(cq.ID, answer)); err != nil {
+			log.Printf("Answer callback error: %v", err)
+		}
+	}()
+
+	if cq.Message == nil {
+		return
+	}
+
+	user, err := h.auth.AuthenticateTelegramUser(
+		cq.From.ID,
+		cq.From.UserName,
+		cq.From.FirstName,
+		cq.From.LastName,
+	)
+	if err != nil {
+		lang, _ := i18n.Match(cq.From.LanguageCode)
+		answer = i18n.Get(lang).T("auth_error")
+		log.Printf("Auth error: %v", err)
+		return
+	}
+	h.detectLanguage(user.TelegramID, cq.From.LanguageCode)
+	lc := h.locale(user.TelegramID)
+
+	cb, err := decodeCallback(cq.Data)
+	if err != nil {
+		answer = lc.T("button_expired")
+		log.Printf("Decode callback error: %v", err)
+		return
+	}
+
+	// Для большинства кнопок первый аргумент - ID мероприятия
+	eventID, ok := cb.arg(0)
+	if !ok && cb.Action != actionDismiss && cb.Action != actionEditDone {
+		answer = lc.T("button_expired")
+		return
+	}
+
+	chatID := cq.Message.Chat.ID
+
+	switch cb.Action {
+	case actionDetails:
+		occurrence, _ := cb.arg(1)
+		answer = h.handleEventDetails(chatID, user, eventID, occurrence)
+	case actionJoin:
+		answer = h.setAttendance(chatID, user, eventID, models.AttendanceGoing)
+	case actionMaybe:
+		answer = h.setAttendance(chatID, user, eventID, models.AttendanceMaybe)
+	case actionLeave:
+		answer = h.setAttendance(chatID, user, eventID, models.AttendanceNotGoing)
+	case actionEdit:
+		occurrence, _ := cb.arg(1)
+		answer = h.startEditEvent(chatID, user, eventID, occurrence)
+	case actionEditScope:
+		occurrence, _ := cb.arg(1)
+		scope, _ := cb.arg(2)
+		answer = h.handleEditScope(chatID, user, eventID, occurrence, scope)
+	case actionEditField:
+		fieldIdx, _ := cb.arg(1)
+		answer = h.handleEditFieldButton(chatID, user, eventID, fieldIdx)
+	case actionEditDone:
+		answer = h.handleEditDoneButton(chatID, user)
+	case actionDelete:
+		answer = h.handleDeleteRequest(chatID, user, eventID)
+	case actionDeleteConfirm:
+		answer = h.handleDeleteConfirm(cq.Message, user, eventID)
+	case actionTimezone:
+		answer = h.handleTimezoneButton(user, eventID)
+	case actionLanguage:
+		answer = h.handleLanguageButton(user, eventID)
+	case actionSettings:
+		answer = h.handleSettingsButton(cq.Message, user, eventID)
+	case actionICS:
+		answer = h.handleICSButton(chatID, user, eventID)
+	case actionEventsPage:
+		page, _ := cb.arg(1)
+		backward, _ := cb.arg(2)
+		cursorNano, _ := cb.arg(3)
+		cursorID, _ := cb.arg(4)
+		answer = h.handleEventsPageButton(cq.Message, user, eventID, page, backward, cursorNano, cursorID)
+	case actionSearchPage:
+		page, _ := cb.arg(1)
+		answer = h.handleSearchPageButton(cq.Message, user, eventID, page)
+	case actionDismiss:
+		h.deleteMessage(chatID, cq.Message.MessageID)
+	default:
+		answer = lc.T("unknown_action")
+	}
+}
+
+// Аргументы кнопок мероприятия: ID, а у повторения серии - ещё и его начало
+func occurrenceArgs(event *models.Event) []int64 {
+	if event.IsRecurring() {
+		return []int64{event.ID, event.EventDate.Unix()}
+	}
+	return []int64{event.ID}
+}
+
+// Кнопки под мероприятием в списке
+func eventButtons(event *models.Event, label string) []tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("ℹ️"+label, encodeCallback(actionDetails, occurrenceArgs(event)...)),
+		tgbotapi.NewInlineKeyboardButtonData("✅"+label, encodeCallback(actionJoin, event.ID)),
+		tgbotapi.NewInlineKeyboardButtonData("✏️"+label, encodeCallback(actionEdit, occurrenceArgs(event)...)),
+		tgbotapi.NewInlineKeyboardButtonData("🗑"+label, encodeCallback(actionDelete, event.ID)),
+	)
+}
+
+// Кнопки карточки мероприятия
+func eventDetailsKeyboard(lc *i18n.Locale, event *models.Event) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		attendanceButtons(lc, event.ID),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lc.T("button_edit"), encodeCallback(actionEdit, occurrenceArgs(event)...)),
+			tgbotapi.NewInlineKeyboardButtonData(lc.T("button_delete"), encodeCallback(actionDelete, event.ID)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lc.T("button_calendar"), encodeCallback(actionICS, event.ID)),
+		),
+	)
+}
@@ -0,0 +1,412 @@
+package bot
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"event-planner-bot/internal/dateparse"
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	dialogCreateEvent = "create_event"
+
+	maxTitleLength       = 100
+	maxDescriptionLength = 1000
+	maxLocationLength    = 200
+)
+
+// Мастер создания мероприятия
+var createEventWizard = &wizard{
+	steps: []wizardStep{
+		{
+			name:   "title",
+			prompt: func(d *dialog) string { return render(d.locale(), "create_title", nil) },
+			apply: func(d *dialog, text string) error {
+				return setText(d, "title", text, maxTitleLength, false)
+			},
+		},
+		{
+			name: "description",
+			prompt: func(d *dialog) string {
+				return render(d.locale(), "create_description", nil)
+			},
+			apply: func(d *dialog, text string) error {
+				return setText(d, "description", text, maxDescriptionLength, true)
+			},
+		},
+		{
+			name: "date",
+			prompt: func(d *dialog) string {
+				return render(d.locale(), "create_date", nil)
+			},
+			apply: func(d *dialog, text string) error {
+				return setEventDate(d, text, time.Now())
+			},
+		},
+		{
+			name: "time",
+			prompt: func(d *dialog) string {
+				return render(d.locale(), "create_time", dialogLocation(d).String())
+			},
+			apply: func(d *dialog, text string) error {
+				return setEventTime(d, text, time.Now())
+			},
+			// Время уже указано вместе с датой
+			skip: func(d *dialog) bool { return d.Data["time_set"] != "" },
+		},
+		{
+			name: "recurrence",
+			prompt: func(d *dialog) string {
+				return render(d.locale(), "create_recurrence", nil)
+			},
+			apply: func(d *dialog, text string) error {
+				rule, err := parseRepeat(d.locale(), text)
+				if err != nil {
+					return err
+				}
+				d.Data["recurrence"] = rule
+				return nil
+			},
+		},
+		{
+			name:   "location",
+			prompt: func(d *dialog) string { return render(d.locale(), "create_location", nil) },
+			apply: func(d *dialog, text string) error {
+				return setText(d, "location", text, maxLocationLength, false)
+			},
+		},
+		{
+			name: "capacity",
+			prompt: func(d *dialog) string {
+				return render(d.locale(), "create_capacity", nil)
+			},
+			apply: func(d *dialog, text string) error {
+				capacity, err := parseCapacity(d.locale(), text)
+				if err != nil {
+					return err
+				}
+				d.Data["capacity"] = strconv.Itoa(capacity)
+				return nil
+			},
+		},
+		{
+			name: "confirm",
+			prompt: func(d *dialog) string {
+				return render(d.locale(), "create_confirm", eventSummary(d))
+			},
+			apply: func(d *dialog, text string) error {
+				switch strings.ToLower(text) {
+				case "да", "yes", "+":
+					return nil
+				}
+				return userError(d.locale().T("answer_unclear"))
+			},
+		},
+	},
+	finish: finishCreateEvent,
+}
+
+func init() {
+	wizards[dialogCreateEvent] = createEventWizard
+}
+
+// /create - запуск мастера создания мероприятия
+func (h *BotHandler) handleCreateEvent(msg *tgbotapi.Message, user *models.User) {
+	h.sendMessage(msg.Chat.ID, h.locale(user.TelegramID).T("create_start"))
+	h.startDialog(msg.Chat.ID, user.TelegramID, dialogCreateEvent, map[string]string{
+		"tz": h.userLocation(user.TelegramID).String(),
+	})
+}
+
+func finishCreateEvent(h *BotHandler, msg *tgbotapi.Message, user *models.User, d *dialog) {
+	chatID := msg.Chat.ID
+	lc := d.locale()
+
+	start, end, allDay, err := eventTimes(d)
+	if err != nil {
+		h.sendMessage(chatID, lc.T("create_error"))
+		log.Printf("Create event error: %v", err)
+		return
+	}
+
+	capacity, _ := strconv.Atoi(d.Data["capacity"])
+
+	event := &models.Event{
+		Title:       d.Data["title"],
+		Description: d.Data["description"],
+		EventDate:   start,
+		EndDate:     end,
+		AllDay:      allDay,
+		Timezone:    d.Data["tz"],
+		Recurrence:  d.Data["recurrence"],
+		Location:    d.Data["location"],
+		Capacity:    capacity,
+		CreatedBy:   user.TelegramID,
+		ChatID:      chatID,
+	}
+
+	if err := h.repo.CreateEvent(event); err != nil {
+		h.sendMessage(chatID, lc.T("create_error"))
+		log.Printf("Create event error: %v", err)
+		return
+	}
+
+	h.sendMessage(chatID, render(lc, "create_done", eventSummary(d)))
+}
+
+// Сводка по введённым данным мероприятия для шаблона event_summary
+type eventSummaryData struct {
+	Title       string
+	Description string
+	Date        string
+	Time        string
+	Timezone    string
+	Recurrence  string // RRULE
+	Location    string
+	Capacity    string
+}
+
+func eventSummary(d *dialog) eventSummaryData {
+	capacity, _ := strconv.Atoi(d.Data["capacity"])
+
+	return eventSummaryData{
+		Title:       d.Data["title"],
+		Description: d.Data["description"],
+		Date:        formatDialogDate(d),
+		Time:        formatDialogTime(d),
+		Timezone:    d.Data["tz"],
+		Recurrence:  d.Data["recurrence"],
+		Location:    d.Data["location"],
+		Capacity:    formatCapacity(d.locale(), capacity),
+	}
+}
+
+// Дата из ответов мастера в формате языка пользователя
+func formatDialogDate(d *dialog) string {
+	date, err := time.Parse("2006-01-02", d.Data["date"])
+	if err != nil {
+		return d.Data["date"]
+	}
+	return d.locale().Date(date)
+}
+
+// Время из ответов мастера: «19:30–21:00», «19:30» или «весь день»
+func formatDialogTime(d *dialog) string {
+	lc := d.locale()
+	clock := func(text string) string {
+		if t, err := time.Parse("15:04", text); err == nil {
+			return lc.Clock(t)
+		}
+		return text
+	}
+
+	switch {
+	case d.Data["all_day"] != "":
+		return lc.T("all_day")
+	case d.Data["end_time"] != "":
+		return clock(d.Data["time"]) + "–" + clock(d.Data["end_time"])
+	}
+	return clock(d.Data["time"])
+}
+
+// Часовой пояс, в котором пользователь вводит дату и время: при
+// редактировании - пояс редактирующего (input_tz), иначе пояс мероприятия;
+// если его нет в диалоге - пояс по умолчанию из настроек бота
+func dialogLocation(d *dialog) *time.Location {
+	for _, key := range []string{"input_tz", "tz"} {
+		if loc, err := models.ParseTimezone(d.Data[key]); err == nil {
+			return loc
+		}
+	}
+	if d.defaultLocation != nil {
+		return d.defaultLocation
+	}
+	return time.UTC
+}
+
+// Проверка и сохранение ответа на шаг даты. Если вместе с датой
+// указано время, шаг времени пропускается
+func setEventDate(d *dialog, text string, now time.Time) error {
+	loc := dialogLocation(d)
+	lc := d.locale()
+
+	parsed, err := dateparse.Parse(text, now, loc)
+	if err != nil {
+		return dateparseError(lc, err, lc.T("date_hint"))
+	}
+	if !parsed.HasDate {
+		return userError(lc.T("date_required"))
+	}
+
+	date := parsed.Time.In(loc)
+	d.Data["date"] = date.Format("2006-01-02")
+
+	if parsed.HasTime {
+		d.Data["time_set"] = "1"
+		return setEventTime(d, date.Format("15:04"), now)
+	}
+
+	delete(d.Data, "time_set")
+	now = now.In(loc)
+	if date.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)) {
+		return userError(lc.T("date_passed"))
+	}
+	return nil
+}
+
+// Проверка и сохранение ответа на шаг времени
+func setEventTime(d *dialog, text string, now time.Time) error {
+	lc := d.locale()
+	text = strings.TrimSpace(text)
+	switch strings.ToLower(text) {
+	case "весь день", "all day", "allday":
+		d.Data["all_day"] = "1"
+		d.Data["time"] = ""
+		d.Data["end_time"] = ""
+	default:
+		startText, endText, hasEnd := strings.Cut(strings.ReplaceAll(text, "–", "-"), "-")
+		loc := dialogLocation(d)
+
+		start, err := parseClock(lc, startText, now, loc)
+		if err != nil {
+			return err
+		}
+		d.Data["all_day"] = ""
+		d.Data["time"] = start
+		d.Data["end_time"] = ""
+
+		if hasEnd {
+			end, err := parseClock(lc, endText, now, loc)
+			if err != nil {
+				return err
+			}
+			if end == start {
+				return userError(lc.T("time_end_equals_start"))
+			}
+			d.Data["end_time"] = end
+		}
+	}
+
+	start, end, allDay, err := eventTimes(d)
+	if err != nil {
+		return err
+	}
+	if (allDay && !end.After(now)) || (!allDay && !start.After(now)) {
+		return userError(lc.T("time_passed"))
+	}
+	return nil
+}
+
+// Начало и окончание мероприятия по ответам мастера.
+// Окончание раньше начала означает, что мероприятие заканчивается на следующий день
+func eventTimes(d *dialog) (start, end time.Time, allDay bool, err error) {
+	loc := dialogLocation(d)
+	lc := d.locale()
+
+	if d.Data["all_day"] != "" {
+		start, err = time.ParseInLocation("2006-01-02", d.Data["date"], loc)
+		if err != nil {
+			return
+		}
+		return start, start.AddDate(0, 0, 1), true, nil
+	}
+
+	start, err = parseTimeOfDay(lc, d.Data["date"], d.Data["time"], loc)
+	if err != nil {
+		return
+	}
+	if d.Data["end_time"] == "" {
+		return start, start.Add(models.DefaultEventDuration), false, nil
+	}
+
+	end, err = parseTimeOfDay(lc, d.Data["date"], d.Data["end_time"], loc)
+	if err != nil {
+		return
+	}
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end, false, nil
+}
+
+// Проверка и сохранение текстового поля
+func setText(d *dialog, key, text string, maxLength int, optional bool) error {
+	if optional && text == "-" {
+		d.Data[key] = ""
+		return nil
+	}
+	if text == "" {
+		return userError(d.locale().T("value_empty"))
+	}
+	if utf8.RuneCountInString(text) > maxLength {
+		return userError(d.locale().N("value_too_long", maxLength))
+	}
+	d.Data[key] = text
+	return nil
+}
+
+// Разбор количества мест: число или «-» (без ограничений)
+func parseCapacity(lc *i18n.Locale, text string) (int, error) {
+	if text == "-" || text == "0" {
+		return 0, nil
+	}
+	capacity, err := strconv.Atoi(text)
+	if err != nil || capacity < 0 {
+		return 0, userError(lc.T("capacity_invalid"))
+	}
+	if capacity > 100000 {
+		return 0, userError(lc.T("capacity_too_many"))
+	}
+	return capacity, nil
+}
+
+// Время суток из ответа пользователя («19:30», «7pm», «в 7 вечера») в виде ЧЧ:ММ
+func parseClock(lc *i18n.Locale, text string, now time.Time, loc *time.Location) (string, error) {
+	parsed, err := dateparse.Parse(text, now, loc)
+	if err != nil {
+		return "", dateparseError(lc, err, lc.T("time_hint"))
+	}
+	if parsed.HasDate || !parsed.HasTime {
+		return "", userError(lc.T("time_only"))
+	}
+	return parsed.Time.Format("15:04"), nil
+}
+
+// Ошибка разбора даты для пользователя: при неоднозначном вводе
+// перечисляем варианты, иначе показываем подсказку hint
+func dateparseError(lc *i18n.Locale, err error, hint string) error {
+	var ambiguous *dateparse.AmbiguousError
+	if !errors.As(err, &ambiguous) {
+		return userError(lc.T("date_unclear", hint))
+	}
+
+	options := make([]string, 0, len(ambiguous.Options))
+	for _, option := range ambiguous.Options {
+		text := lc.Clock(option.Time)
+		switch {
+		case option.HasDate && option.HasTime:
+			text = lc.DateTime(option.Time)
+		case option.HasDate:
+			text = lc.Date(option.Time)
+		}
+		options = append(options, lc.T("quoted", text))
+	}
+	return userError(lc.T("date_ambiguous", strings.Join(options, lc.T("or"))))
+}
+
+// Разбор времени ЧЧ:ММ для даты в формате ГГГГ-ММ-ДД
+func parseTimeOfDay(lc *i18n.Locale, date, text string, loc *time.Location) (time.Time, error) {
+	t, err := time.ParseInLocation("2006-01-02 15:04", date+" "+strings.TrimSpace(text), loc)
+	if err != nil {
+		return time.Time{}, userError(lc.T("time_invalid"))
+	}
+	return t, nil
+}
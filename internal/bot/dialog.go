@@ -0,0 +1,242 @@
+package bot
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Через сколько заброшенный диалог истекает
+const dialogTTL = 24 * time.Hour
+
+// Состояние пошагового диалога с пользователем
+type dialog struct {
+	Kind string            // какой мастер запущен
+	Step string            // текущий шаг
+	Data map[string]string // ответы, собранные на предыдущих шагах
+
+	// Пояс DEFAULT_TIMEZONE на случай, если в Data нет пояса; не сохраняется
+	defaultLocation *time.Location
+}
+
+// Шаг мастера: вопрос и обработка ответа
+type wizardStep struct {
+	name string
+	// prompt - текст вопроса в разметке HTML, обычно из render
+	prompt func(d *dialog) string
+	// apply проверяет ответ и сохраняет его в d.Data.
+	// Ошибка userError показывается пользователю, шаг повторяется
+	apply func(d *dialog, text string) error
+	// keyboard - необязательные кнопки под вопросом
+	keyboard func(d *dialog) *tgbotapi.InlineKeyboardMarkup
+	// skip - необязательная проверка, что ответ уже получен на другом шаге
+	skip func(d *dialog) bool
+}
+
+// Пошаговый мастер
+type wizard struct {
+	steps  []wizardStep
+	finish func(h *BotHandler, msg *tgbotapi.Message, user *models.User, d *dialog)
+}
+
+// Ответ, которым пользователь завершает диалог досрочно
+var errDialogDone = errors.New("диалог завершён")
+
+// Язык диалога. Запоминается в d.Data["lang"] при каждом шаге,
+// чтобы вопросы и ошибки проверки были на языке пользователя
+func (d *dialog) locale() *i18n.Locale {
+	return i18n.Get(d.Data["lang"])
+}
+
+// Ошибка проверки ответа, которую можно показать пользователю
+type userError string
+
+func (e userError) Error() string { return string(e) }
+
+// Зарегистрированные мастера по типу диалога
+var wizards = map[string]*wizard{}
+
+func (w *wizard) stepIndex(name string) int {
+	for i, step := range w.steps {
+		if step.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Соседний шаг в направлении dir (1 или -1) с учётом пропускаемых;
+// -1 или len(w.steps), если шагов в этом направлении больше нет
+func (w *wizard) nextStep(d *dialog, idx, dir int) int {
+	for idx += dir; idx >= 0 && idx < len(w.steps); idx += dir {
+		if w.steps[idx].skip == nil || !w.steps[idx].skip(d) {
+			return idx
+		}
+	}
+	return idx
+}
+
+// Получение активного диалога пользователя в чате
+func (h *BotHandler) getDialog(chatID, userID int64) *dialog {
+	session, err := h.repo.GetSession(chatID, userID)
+	if err != nil {
+		log.Printf("Get session error: %v", err)
+		return nil
+	}
+	if session == nil {
+		return nil
+	}
+	if session.Data == nil {
+		session.Data = make(map[string]string)
+	}
+	return &dialog{Kind: session.Kind, Step: session.Step, Data: session.Data, defaultLocation: h.defaultLocation}
+}
+
+// Сохранение диалога; срок жизни отсчитывается заново
+func (h *BotHandler) saveDialog(chatID, userID int64, d *dialog) {
+	session := &models.Session{
+		ChatID:    chatID,
+		UserID:    userID,
+		Kind:      d.Kind,
+		Step:      d.Step,
+		Data:      d.Data,
+		ExpiresAt: time.Now().Add(dialogTTL),
+	}
+	if err := h.repo.SaveSession(session); err != nil {
+		log.Printf("Save session error: %v", err)
+	}
+}
+
+func (h *BotHandler) deleteDialog(chatID, userID int64) {
+	if err := h.repo.DeleteSession(chatID, userID); err != nil {
+		log.Printf("Delete session error: %v", err)
+	}
+}
+
+// Запуск мастера с первого шага
+func (h *BotHandler) startDialog(chatID, userID int64, kind string, data map[string]string) {
+	w := wizards[kind]
+	if data == nil {
+		data = make(map[string]string)
+	}
+
+	d := &dialog{Kind: kind, Step: w.steps[0].name, Data: data, defaultLocation: h.defaultLocation}
+	d.Data["lang"] = h.userLanguage(userID)
+	h.saveDialog(chatID, userID, d)
+	h.sendPrompt(chatID, w.steps[0], d, "")
+}
+
+// Отправка вопроса шага; prefix - необязательный текст без разметки перед вопросом
+func (h *BotHandler) sendPrompt(chatID int64, step wizardStep, d *dialog, prefix string) {
+	text := step.prompt(d)
+	if prefix != "" {
+		text = escapeHTML(prefix) + "\n\n" + text
+	}
+
+	if step.keyboard != nil {
+		if keyboard := step.keyboard(d); keyboard != nil {
+			h.sendMessageWithKeyboard(chatID, text, *keyboard)
+			return
+		}
+	}
+	h.sendMessage(chatID, text)
+}
+
+// Обработка ответа на текущий шаг диалога
+func (h *BotHandler) handleDialogInput(msg *tgbotapi.Message, user *models.User, d *dialog) {
+	chatID := msg.Chat.ID
+	lc := h.locale(user.TelegramID)
+	d.Data["lang"] = lc.Lang
+
+	w, ok := wizards[d.Kind]
+	idx := -1
+	if ok {
+		idx = w.stepIndex(d.Step)
+	}
+	if idx < 0 {
+		h.deleteDialog(chatID, user.TelegramID)
+		h.sendMessage(chatID, lc.T("dialog_expired"))
+		return
+	}
+
+	step := w.steps[idx]
+	if err := step.apply(d, strings.TrimSpace(msg.Text)); err != nil {
+		if errors.Is(err, errDialogDone) {
+			h.deleteDialog(chatID, user.TelegramID)
+			h.sendMessage(chatID, lc.T("done"))
+			return
+		}
+
+		var uerr userError
+		if errors.As(err, &uerr) {
+			h.sendPrompt(chatID, step, d, uerr.Error())
+			return
+		}
+		h.deleteDialog(chatID, user.TelegramID)
+		h.sendMessage(chatID, lc.T("dialog_error"))
+		return
+	}
+
+	next := w.nextStep(d, idx, 1)
+	if next >= len(w.steps) {
+		h.deleteDialog(chatID, user.TelegramID)
+		w.finish(h, msg, user, d)
+		return
+	}
+
+	h.setDialogStep(chatID, user.TelegramID, d, w.steps[next].name)
+}
+
+// Переход диалога на указанный шаг с показом вопроса
+func (h *BotHandler) setDialogStep(chatID, userID int64, d *dialog, stepName string) {
+	w := wizards[d.Kind]
+	idx := w.stepIndex(stepName)
+
+	d.Step = stepName
+	d.Data["lang"] = h.userLanguage(userID)
+	h.saveDialog(chatID, userID, d)
+	h.sendPrompt(chatID, w.steps[idx], d, "")
+}
+
+// /cancel - прервать текущий диалог
+func (h *BotHandler) handleCancel(chatID, userID int64) {
+	lc := h.locale(userID)
+	if h.getDialog(chatID, userID) == nil {
+		h.sendMessage(chatID, lc.T("cancel_nothing"))
+		return
+	}
+
+	h.deleteDialog(chatID, userID)
+	h.sendMessage(chatID, lc.T("cancel_done"))
+}
+
+// /back - вернуться к предыдущему шагу диалога
+func (h *BotHandler) handleBack(chatID, userID int64) {
+	d := h.getDialog(chatID, userID)
+	if d == nil {
+		h.sendMessage(chatID, h.locale(userID).T("back_no_dialog"))
+		return
+	}
+
+	w, ok := wizards[d.Kind]
+	if !ok {
+		h.deleteDialog(chatID, userID)
+		return
+	}
+
+	idx := w.stepIndex(d.Step)
+	prev := w.nextStep(d, idx, -1)
+	if idx <= 0 || prev < 0 {
+		d.Data["lang"] = h.userLanguage(userID)
+		h.sendPrompt(chatID, w.steps[0], d, d.locale().T("back_first_step"))
+		return
+	}
+
+	h.setDialogStep(chatID, userID, d, w.steps[prev].name)
+}
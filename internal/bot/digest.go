@@ -0,0 +1,91 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"event-planner-bot/internal/database"
+	"event-planner-bot/internal/models"
+)
+
+const (
+	// С какого часа по времени пользователя присылать сводку
+	digestHour = 9
+	// Сколько мероприятий показывать в сводке
+	digestSize = 20
+)
+
+// Рассылка сводок предстоящих мероприятий. Вызывается планировщиком:
+// ежедневная сводка - на сегодня, еженедельная - на 7 дней вперёд
+func (h *BotHandler) SendDigests(now time.Time) {
+	subscribers, err := h.repo.GetDigestSubscribers()
+	if err != nil {
+		log.Printf("Get digest subscribers error: %v", err)
+		return
+	}
+
+	for i := range subscribers {
+		h.sendDigest(&subscribers[i], now)
+	}
+}
+
+// Сводка одному пользователю, не больше одной за день или неделю
+func (h *BotHandler) sendDigest(settings *models.UserSettings, now time.Time) {
+	loc := h.userLocation(settings.UserID)
+	local := now.In(loc)
+	if local.Hour() < digestHour {
+		return
+	}
+
+	var period string
+	var to time.Time
+	switch settings.Digest {
+	case models.DigestDaily:
+		period = local.Format("2006-01-02")
+		to = startOfDay(now, loc).AddDate(0, 0, 1)
+	case models.DigestWeekly:
+		year, week := local.ISOWeek()
+		period = fmt.Sprintf("%d-W%02d", year, week)
+		to = now.AddDate(0, 0, 7)
+	default:
+		return
+	}
+
+	// Сводка приходит в личный чат, поэтому в ней мероприятия этого чата
+	filter := database.EventFilter{From: now, To: to, ChatID: settings.UserID}
+	events, _, err := h.repo.FindEventsPage(filter, nil, false, digestSize)
+	if err != nil {
+		log.Printf("Find events error: %v", err)
+		return
+	}
+
+	claimed, err := h.repo.ClaimDigest(settings.UserID, period)
+	if err != nil {
+		log.Printf("Claim digest error: %v", err)
+		return
+	}
+	// Пустую сводку не присылаем, но период всё равно отмечаем
+	if !claimed || len(events) == 0 {
+		return
+	}
+
+	going := h.countGoing(events)
+	items := make([]eventItem, len(events))
+	for i := range events {
+		event := &events[i]
+		items[i] = eventItem{
+			Num:       i + 1,
+			Event:     event,
+			Shown:     event,
+			Loc:       loc,
+			Going:     going[event.ID],
+			ShowGoing: true,
+		}
+	}
+
+	h.sendMessage(settings.UserID, render(h.locale(settings.UserID), "digest", map[string]interface{}{
+		"Weekly": settings.Digest == models.DigestWeekly,
+		"Items":  items,
+	}))
+}
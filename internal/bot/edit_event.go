@@ -0,0 +1,397 @@
+package bot
+
+import (
+	"errors"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-planner-bot/internal/database"
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const dialogEditEvent = "edit_event"
+
+// Поля, которые можно изменить; ключи совпадают с шагами мастера создания,
+// подписи в каталоге - field_<ключ>
+var editableFields = []string{
+	"title",
+	"description",
+	"date",
+	"time",
+	"recurrence",
+	"location",
+	"capacity",
+}
+
+// Подпись поля на языке диалога
+func fieldLabel(lc *i18n.Locale, field string) string {
+	return lc.T("field_" + field)
+}
+
+// Номера полей editableFields, доступных в диалоге: у отдельного
+// повторения серии правило повторения не меняется
+func visibleEditFields(d *dialog) []int {
+	var fields []int
+	for i, field := range editableFields {
+		if field == "recurrence" && d.Data["occurrence"] != "" {
+			continue
+		}
+		fields = append(fields, i)
+	}
+	return fields
+}
+
+// Мастер редактирования: выбор поля -> новое значение -> сохранение,
+// затем снова выбор поля, пока пользователь не ответит «готово»
+var editEventWizard = &wizard{
+	steps: []wizardStep{
+		{
+			name: "field",
+			prompt: func(d *dialog) string {
+				lc := d.locale()
+				var fields []string
+				for _, idx := range visibleEditFields(d) {
+					fields = append(fields, fieldLabel(lc, editableFields[idx]))
+				}
+				return render(lc, "edit_field", map[string]interface{}{
+					"Title":  d.Data["title"],
+					"Fields": fields,
+				})
+			},
+			apply: func(d *dialog, text string) error {
+				lc := d.locale()
+				text = strings.ToLower(text)
+				if text == "готово" || text == "done" {
+					return errDialogDone
+				}
+				for i, idx := range visibleEditFields(d) {
+					field := editableFields[idx]
+					if text == strconv.Itoa(i+1) || text == strings.ToLower(fieldLabel(lc, field)) {
+						d.Data["field"] = field
+						return nil
+					}
+				}
+				return userError(lc.T("field_unclear"))
+			},
+			keyboard: func(d *dialog) *tgbotapi.InlineKeyboardMarkup {
+				eventID, _ := strconv.ParseInt(d.Data["event_id"], 10, 64)
+				lc := d.locale()
+
+				var rows [][]tgbotapi.InlineKeyboardButton
+				var row []tgbotapi.InlineKeyboardButton
+				for _, idx := range visibleEditFields(d) {
+					row = append(row, tgbotapi.NewInlineKeyboardButtonData(fieldLabel(lc, editableFields[idx]),
+						encodeCallback(actionEditField, eventID, int64(idx))))
+					if len(row) == 2 {
+						rows = append(rows, row)
+						row = nil
+					}
+				}
+				if len(row) > 0 {
+					rows = append(rows, row)
+				}
+				rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+					tgbotapi.NewInlineKeyboardButtonData(lc.T("button_done"), encodeCallback(actionEditDone)),
+				))
+
+				keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+				return &keyboard
+			},
+		},
+		{
+			name: "value",
+			prompt: func(d *dialog) string {
+				step := createEventStep(d.Data["field"])
+				return render(d.locale(), "edit_current", currentFieldValue(d)) + "\n\n" + step.prompt(d)
+			},
+			apply: func(d *dialog, text string) error {
+				return createEventStep(d.Data["field"]).apply(d, text)
+			},
+		},
+	},
+	finish: finishEditEvent,
+}
+
+func init() {
+	wizards[dialogEditEvent] = editEventWizard
+}
+
+// Шаг мастера создания с тем же ключом, что и поле
+func createEventStep(field string) wizardStep {
+	idx := createEventWizard.stepIndex(field)
+	if idx < 0 {
+		idx = 0
+	}
+	return createEventWizard.steps[idx]
+}
+
+// Текущее значение редактируемого поля для подсказки
+func currentFieldValue(d *dialog) string {
+	value := d.Data[d.Data["field"]]
+	switch d.Data["field"] {
+	case "date":
+		return formatDialogDate(d)
+	case "time":
+		return formatDialogTime(d)
+	case "recurrence":
+		return describeRecurrence(d.locale(), value)
+	case "capacity":
+		n, _ := strconv.Atoi(value)
+		return formatCapacity(d.locale(), n)
+	}
+	if value == "" {
+		return "—"
+	}
+	return value
+}
+
+// /edit ID - изменить мероприятие
+func (h *BotHandler) handleEditEvent(msg *tgbotapi.Message, user *models.User) {
+	eventID, ok := parseIDArgument(msg)
+	if !ok {
+		h.sendMessage(msg.Chat.ID, h.locale(user.TelegramID).T("edit_usage"))
+		return
+	}
+
+	if answer := h.startEditEvent(msg.Chat.ID, user, eventID, 0); answer != "" {
+		h.sendMessage(msg.Chat.ID, answer)
+	}
+}
+
+// Запуск мастера редактирования; непустой результат - текст отказа.
+// Для серии сначала спрашиваем, менять ли повторение occurrence
+// (Unix-время начала, 0 - ближайшее) или всю серию
+func (h *BotHandler) startEditEvent(chatID int64, user *models.User, eventID, occurrence int64) string {
+	lc := h.locale(user.TelegramID)
+	event, answer := h.loadEvent(lc, eventID)
+	if event == nil {
+		return answer
+	}
+	if !h.canManageEvent(user, event) {
+		return forbiddenText(lc, event, "edit_forbidden")
+	}
+	if event.Status == models.StatusEnded {
+		return lc.T("event_already_ended")
+	}
+	if event.IsRecurring() {
+		return h.askEditScope(chatID, user, event, occurrence)
+	}
+
+	h.startDialog(chatID, user.TelegramID, dialogEditEvent, eventDialogData(event, h.userLocation(user.TelegramID)))
+	return ""
+}
+
+// Данные диалога из мероприятия (в формате ответов мастера создания).
+// В tz остаётся пояс мероприятия, чтобы правка не переносила серию в другой
+// пояс. Время показывается и вводится в поясе редактирующего (input_tz),
+// а дата мероприятия на весь день - в поясе, в котором его создали
+func eventDialogData(event *models.Event, loc *time.Location) map[string]string {
+	eventLoc := event.TimeLocation(loc)
+	if event.AllDay {
+		loc = eventLoc
+	}
+
+	start := event.EventDate.In(loc)
+	data := map[string]string{
+		"event_id":    strconv.FormatInt(event.ID, 10),
+		"tz":          eventLoc.String(),
+		"input_tz":    loc.String(),
+		"title":       event.Title,
+		"description": event.Description,
+		"date":        start.Format("2006-01-02"),
+		"recurrence":  event.Recurrence,
+		"location":    event.Location,
+		"capacity":    strconv.Itoa(event.Capacity),
+	}
+	if event.AllDay {
+		data["all_day"] = "1"
+	} else {
+		data["time"] = start.Format("15:04")
+		data["end_time"] = event.EndTime().In(loc).Format("15:04")
+	}
+	return data
+}
+
+// Кнопка выбора поля для редактирования
+func (h *BotHandler) handleEditFieldButton(chatID int64, user *models.User, eventID int64, fieldIdx int64) string {
+	lc := h.locale(user.TelegramID)
+	if fieldIdx < 0 || fieldIdx >= int64(len(editableFields)) {
+		return lc.T("field_unknown")
+	}
+
+	d := h.getDialog(chatID, user.TelegramID)
+	if d == nil || d.Kind != dialogEditEvent || d.Data["event_id"] != strconv.FormatInt(eventID, 10) {
+		// Кнопка из старого сообщения: начинаем редактирование заново
+		event, answer := h.loadEvent(lc, eventID)
+		if event == nil {
+			return answer
+		}
+		if !h.canManageEvent(user, event) {
+			return forbiddenText(lc, event, "edit_forbidden")
+		}
+		if event.IsRecurring() {
+			return h.askEditScope(chatID, user, event, 0)
+		}
+		d = &dialog{Kind: dialogEditEvent, Data: eventDialogData(event, h.userLocation(user.TelegramID)), defaultLocation: h.defaultLocation}
+	}
+
+	field := editableFields[fieldIdx]
+	if field == "recurrence" && d.Data["occurrence"] != "" {
+		return lc.T("recurrence_series_only")
+	}
+	d.Data["field"] = field
+	h.setDialogStep(chatID, user.TelegramID, d, "value")
+	return ""
+}
+
+// Кнопка «готово» в мастере редактирования
+func (h *BotHandler) handleEditDoneButton(chatID int64, user *models.User) string {
+	d := h.getDialog(chatID, user.TelegramID)
+	if d != nil && d.Kind == dialogEditEvent {
+		h.deleteDialog(chatID, user.TelegramID)
+	}
+	return h.locale(user.TelegramID).T("done")
+}
+
+func finishEditEvent(h *BotHandler, msg *tgbotapi.Message, user *models.User, d *dialog) {
+	chatID := msg.Chat.ID
+	lc := d.locale()
+
+	eventID, _ := strconv.ParseInt(d.Data["event_id"], 10, 64)
+	event, answer := h.loadEvent(lc, eventID)
+	if event == nil {
+		h.sendMessage(chatID, answer)
+		return
+	}
+	if !h.canManageEvent(user, event) {
+		h.sendMessage(chatID, forbiddenText(lc, event, "edit_forbidden"))
+		return
+	}
+
+	// Через реестр, а не editEventWizard, чтобы не было цикла инициализации
+	fieldStep := wizards[dialogEditEvent].steps[0]
+
+	// Изменяется одно повторение серии: сравниваем с ним, а не с серией
+	current := event
+	var occurrence time.Time
+	if unix, err := strconv.ParseInt(d.Data["occurrence"], 10, 64); err == nil {
+		occurrence = time.Unix(unix, 0)
+		current, err = h.repo.GetOccurrence(event, occurrence)
+		if err != nil {
+			log.Printf("Get occurrence error: %v", err)
+		}
+		if current == nil {
+			h.sendMessage(chatID, lc.T("occurrence_gone"))
+			return
+		}
+	}
+
+	updated := *current
+	updated.Title = d.Data["title"]
+	updated.Description = d.Data["description"]
+	updated.Location = d.Data["location"]
+	updated.Capacity, _ = strconv.Atoi(d.Data["capacity"])
+	if occurrence.IsZero() {
+		updated.Recurrence = d.Data["recurrence"]
+	}
+
+	// Время пересчитывается из ответов, только если его и меняли:
+	// иначе ответы в формате мастера обрезали бы многодневные мероприятия
+	start, end, allDay := current.EventDate, current.EndTime(), current.AllDay
+	if field := d.Data["field"]; field == "date" || field == "time" {
+		var err error
+		start, end, allDay, err = eventTimes(d)
+		if err != nil {
+			h.sendMessage(chatID, lc.T("event_save_error"))
+			log.Printf("Edit event error: %v", err)
+			return
+		}
+		// Окончание заново не указано - сохраняем прежнюю длительность
+		sameEnd := d.Data["end_time"] == "" || field == "date" && d.Data["time_set"] == ""
+		if allDay == current.AllDay && sameEnd {
+			end = keepDuration(current, start)
+		}
+	}
+	// Мероприятие на весь день можно перенести на сегодня, пока день не закончился
+	passed := !start.After(time.Now())
+	if allDay {
+		passed = !end.After(time.Now())
+	}
+	// Изменение не сохраняется: возвращаемся к выбору поля с прежними данными
+	reject := func(text string) {
+		occurrenceKey := d.Data["occurrence"]
+		d.Data = eventDialogData(current, h.userLocation(user.TelegramID))
+		d.Data["lang"] = lc.Lang
+		if occurrenceKey != "" {
+			d.Data["occurrence"] = occurrenceKey
+		}
+		d.Step = fieldStep.name
+		h.saveDialog(chatID, user.TelegramID, d)
+		h.sendPrompt(chatID, fieldStep, d, text)
+	}
+	if !start.Equal(current.EventDate) && passed {
+		reject(lc.T("edit_time_passed"))
+		return
+	}
+	updated.EventDate = start
+	updated.EndDate = end
+	updated.AllDay = allDay
+
+	saved := lc.T("edit_saved")
+	var err error
+	if occurrence.IsZero() {
+		err = h.repo.UpdateEvent(&updated)
+	} else {
+		// Повторение становится отдельным мероприятием, дальше редактируем его
+		err = h.repo.DetachOccurrence(event.ID, occurrence, &updated)
+		if err == nil {
+			d.Data["event_id"] = strconv.FormatInt(updated.ID, 10)
+			delete(d.Data, "occurrence")
+			saved = lc.T("edit_saved_detached", updated.ID)
+		}
+	}
+	if errors.Is(err, database.ErrDetachedOccurrences) {
+		reject(lc.T("edit_rule_detached"))
+		return
+	}
+	if err != nil {
+		h.sendMessage(chatID, "❌ "+lc.T("event_save_error"))
+		log.Printf("Update event error: %v", err)
+		return
+	}
+	log.Printf("Пользователь %d изменил мероприятие %d", user.TelegramID, updated.ID)
+	h.notifyEventChanged(current, &updated, user.TelegramID)
+
+	// Больше мест - переводим людей из очереди
+	if updated.Capacity == 0 || updated.Capacity > current.Capacity {
+		promoted, err := h.repo.PromoteWaitlist(updated.ID)
+		if err != nil {
+			log.Printf("Promote waitlist error: %v", err)
+		}
+		h.notifyPromoted(&updated, promoted)
+	}
+
+	// Продолжаем редактирование с обновлёнными данными
+	delete(d.Data, "field")
+	d.Step = fieldStep.name
+	h.saveDialog(chatID, user.TelegramID, d)
+	h.sendPrompt(chatID, fieldStep, d, saved)
+}
+
+// Окончание мероприятия, перенесённого на start, с прежней длительностью.
+// Мероприятие на весь день сохраняет число дней, даже если между ними
+// переводят часы
+func keepDuration(event *models.Event, start time.Time) time.Time {
+	duration := event.EndTime().Sub(event.EventDate)
+	if event.AllDay {
+		days := int(math.Round(duration.Hours() / 24))
+		return start.AddDate(0, 0, days)
+	}
+	return start.Add(duration)
+}
@@ -0,0 +1,239 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Может ли пользователь изменять и удалять мероприятие. Мероприятиями
+// группы управляют только администраторы этой группы: ни создателю,
+// ни глобальных прав админа бота для них недостаточно
+func (h *BotHandler) canManageEvent(user *models.User, event *models.Event) bool {
+	if isGroupChat(event.ChatID) {
+		isChatAdmin, err := h.auth.IsChatAdmin(event.ChatID, user.TelegramID)
+		if err != nil {
+			log.Printf("IsChatAdmin error: %v", err)
+			return false
+		}
+		return isChatAdmin
+	}
+
+	if event.CreatedBy == user.TelegramID {
+		return true
+	}
+
+	isAdmin, err := h.auth.IsAdmin(user.TelegramID)
+	if err != nil {
+		log.Printf("IsAdmin error: %v", err)
+		return false
+	}
+	return isAdmin
+}
+
+// Отказ в управлении мероприятием: key - для обычных мероприятий,
+// для мероприятий группы - group_<key>
+func forbiddenText(lc *i18n.Locale, event *models.Event, key string) string {
+	if isGroupChat(event.ChatID) {
+		return lc.T("group_" + key)
+	}
+	return lc.T(key)
+}
+
+// Загрузка мероприятия для кнопки; пустое событие - текст ответа на нажатие
+func (h *BotHandler) loadEvent(lc *i18n.Locale, eventID int64) (*models.Event, string) {
+	event, err := h.repo.GetEventByID(eventID)
+	if err != nil {
+		log.Printf("Get event error: %v", err)
+		return nil, lc.T("event_get_error")
+	}
+	if event == nil {
+		return nil, lc.T("event_not_found")
+	}
+	return event, ""
+}
+
+// Подпись статуса мероприятия: «🗓 Запланировано»
+func statusLabel(lc *i18n.Locale, status models.EventStatus) string {
+	if status == "" {
+		status = models.StatusPlanned
+	}
+	return lc.T("status_" + string(status))
+}
+
+// Метка статуса для списков; у запланированных мероприятий её нет
+func statusMark(lc *i18n.Locale, status models.EventStatus) string {
+	if status == "" || status == models.StatusPlanned {
+		return ""
+	}
+	return " — " + statusLabel(lc, status)
+}
+
+// Метка повторяющегося мероприятия для списков
+func recurringMark(event *models.Event) string {
+	if event.IsRecurring() {
+		return " 🔁"
+	}
+	return ""
+}
+
+// Подробная карточка мероприятия со списком участников так, как её видит
+// пользователь viewerID: имена, скрытые настройками приватности, заменяются
+func (h *BotHandler) formatEventDetails(lc *i18n.Locale, event *models.Event, loc *time.Location, viewerID int64) string {
+	attendees, err := h.attendeeGroups(lc, event, viewerID)
+	if err != nil {
+		log.Printf("Get attendees error: %v", err)
+	}
+
+	return render(lc, "event_details", map[string]interface{}{
+		"Event":          event,
+		"Loc":            loc,
+		"Attendees":      attendees,
+		"AttendeesError": err != nil,
+	})
+}
+
+// Кнопка "подробнее". У серии показывается повторение, начинающееся
+// в occurrence (Unix-время), а если оно не указано - ближайшее
+func (h *BotHandler) handleEventDetails(chatID int64, user *models.User, eventID, occurrence int64) string {
+	lc := h.locale(user.TelegramID)
+	event, answer := h.loadEvent(lc, eventID)
+	if event == nil {
+		return answer
+	}
+	if !h.eventVisible(chatID, user, event) {
+		return lc.T("event_not_found")
+	}
+
+	if event.IsRecurring() {
+		var current *models.Event
+		var err error
+		if occurrence != 0 {
+			current, err = h.repo.GetOccurrence(event, time.Unix(occurrence, 0))
+		} else {
+			current, err = h.repo.NextOccurrence(event, time.Now())
+		}
+		if err != nil {
+			log.Printf("Get occurrence error: %v", err)
+		}
+		if current != nil {
+			event = current
+		}
+	}
+
+	text := h.formatEventDetails(lc, event, h.userLocation(user.TelegramID), viewerIn(chatID, user))
+	h.sendMessageWithKeyboard(chatID, text, eventDetailsKeyboard(lc, event))
+	return ""
+}
+
+// Кнопка "удалить": спрашиваем подтверждение
+func (h *BotHandler) handleDeleteRequest(chatID int64, user *models.User, eventID int64) string {
+	lc := h.locale(user.TelegramID)
+	event, answer := h.loadEvent(lc, eventID)
+	if event == nil {
+		return answer
+	}
+	if !h.canManageEvent(user, event) {
+		return forbiddenText(lc, event, "delete_forbidden")
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(lc.T("button_delete_confirm"), encodeCallback(actionDeleteConfirm, event.ID)),
+		tgbotapi.NewInlineKeyboardButtonData(lc.T("button_dismiss"), encodeCallback(actionDismiss)),
+	))
+	h.sendMessageWithKeyboard(chatID, render(lc, "event_delete_ask", event), keyboard)
+	return ""
+}
+
+// Подтверждение удаления
+func (h *BotHandler) handleDeleteConfirm(msg *tgbotapi.Message, user *models.User, eventID int64) string {
+	lc := h.locale(user.TelegramID)
+	event, answer := h.loadEvent(lc, eventID)
+	if event == nil {
+		return answer
+	}
+	if !h.canManageEvent(user, event) {
+		return forbiddenText(lc, event, "delete_forbidden")
+	}
+
+	subscribers := h.eventSubscribers(event.ID)
+
+	if err := h.repo.DeleteEvent(event.ID); err != nil {
+		log.Printf("Delete event error: %v", err)
+		return lc.T("delete_error")
+	}
+
+	log.Printf("Пользователь %d удалил мероприятие %d", user.TelegramID, event.ID)
+	h.notifyEventDeleted(event, subscribers, user.TelegramID)
+	h.editMessage(msg.Chat.ID, msg.MessageID, render(lc, "event_deleted", event))
+	return lc.T("event_deleted")
+}
+
+func formatCapacity(lc *i18n.Locale, capacity int) string {
+	if capacity == 0 {
+		return lc.T("capacity_unlimited")
+	}
+	return strconv.Itoa(capacity)
+}
+
+// Количество идущих, с учётом ограничения мест: "3" или "3/10"
+func formatGoing(going, capacity int) string {
+	if capacity == 0 {
+		return strconv.Itoa(going)
+	}
+	return fmt.Sprintf("%d/%d", going, capacity)
+}
+
+// /cancel_event ID - отменить мероприятие, не удаляя его
+func (h *BotHandler) handleCancelEvent(msg *tgbotapi.Message, user *models.User) {
+	chatID := msg.Chat.ID
+	lc := h.locale(user.TelegramID)
+
+	eventID, ok := parseIDArgument(msg)
+	if !ok {
+		h.sendMessage(chatID, lc.T("cancel_event_usage"))
+		return
+	}
+
+	event, answer := h.loadEvent(lc, eventID)
+	if event == nil {
+		h.sendMessage(chatID, answer)
+		return
+	}
+	if !h.canManageEvent(user, event) {
+		h.sendMessage(chatID, forbiddenText(lc, event, "cancel_forbidden"))
+		return
+	}
+
+	switch event.Status {
+	case models.StatusCancelled:
+		h.sendMessage(chatID, lc.T("event_already_cancelled"))
+		return
+	case models.StatusEnded:
+		h.sendMessage(chatID, lc.T("event_already_ended"))
+		return
+	}
+
+	if err := h.repo.CancelEvent(event.ID); err != nil {
+		h.sendMessage(chatID, lc.T("cancel_error"))
+		log.Printf("Cancel event error: %v", err)
+		return
+	}
+
+	log.Printf("Пользователь %d отменил мероприятие %d", user.TelegramID, event.ID)
+	h.notifyEventCancelled(event, user.TelegramID)
+	h.sendMessage(chatID, render(lc, "event_cancelled", event))
+}
+
+// Обновление статусов мероприятий. Вызывается планировщиком
+func (h *BotHandler) UpdateEventStatuses(now time.Time) {
+	if err := h.repo.UpdateEventStatuses(now); err != nil {
+		log.Printf("Update event statuses error: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package bot
+
+import (
+	"log"
+	"strings"
+
+	"event-planner-bot/internal/auth"
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Групповой чат: у групп и супергрупп ID отрицательные
+func isGroupChat(chatID int64) bool {
+	return chatID < 0
+}
+
+// Сообщение, на которое бот должен ответить. В группе остальные сообщения
+// обращены к людям: бот отвечает только на свои команды и на ввод
+// в начатом диалоге
+func (h *BotHandler) isForBot(msg *tgbotapi.Message) bool {
+	if msg.IsCommand() {
+		return h.isOwnCommand(msg)
+	}
+	return !isGroupChat(msg.Chat.ID) || h.getDialog(msg.Chat.ID, msg.From.ID) != nil
+}
+
+// Команда адресована этому боту: в группе «/events@OtherBot» - команда
+// другому боту, на неё не отвечаем. Сама Command() упоминание отбрасывает
+func (h *BotHandler) isOwnCommand(msg *tgbotapi.Message) bool {
+	_, mention, found := strings.Cut(msg.CommandWithAt(), "@")
+	return !found || strings.EqualFold(mention, h.bot.Self.UserName)
+}
+
+// Доступно ли мероприятие в чате chatID. В чате видны мероприятия,
+// созданные в нём; из других чатов в личном чате - только те, что
+// пользователь создал или на которые ответил, например из напоминаний
+func (h *BotHandler) eventVisible(chatID int64, user *models.User, event *models.Event) bool {
+	if event.ChatID == chatID {
+		return true
+	}
+	if isGroupChat(chatID) {
+		return false
+	}
+	if event.CreatedBy == user.TelegramID {
+		return true
+	}
+
+	status, err := h.repo.GetAttendance(event.ID, user.TelegramID)
+	if err != nil {
+		log.Printf("Get attendance error: %v", err)
+		return false
+	}
+	return status != ""
+}
+
+// Чьими глазами показывать участников: карточку в группе видят все
+// участники группы, поэтому скрытые имена там не показываются никому
+func viewerIn(chatID int64, user *models.User) int64 {
+	if isGroupChat(chatID) {
+		return 0
+	}
+	return user.TelegramID
+}
+
+// Загрузка администраторов группы для auth.AuthService.IsChatAdmin
+func ChatAdministrators(api *tgbotapi.BotAPI) auth.ChatAdminsFunc {
+	return func(chatID int64) ([]int64, error) {
+		members, err := api.GetChatAdministrators(tgbotapi.ChatAdministratorsConfig{
+			ChatConfig: tgbotapi.ChatConfig{ChatID: chatID},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]int64, 0, len(members))
+		for _, member := range members {
+			if member.User != nil {
+				ids = append(ids, member.User.ID)
+			}
+		}
+		return ids, nil
+	}
+}
@@ -1,42 +1,62 @@
 package bot
 
 import (
-	"fmt"
 	"log"
-	"strings"
 	"time"
 
+	"event-planner-bot/config"
 	"event-planner-bot/internal/auth"
 	"event-planner-bot/internal/database"
+	"event-planner-bot/internal/i18n"
 	"event-planner-bot/internal/models"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+// За какой срок вперёд показывать мероприятия в списках
+const eventsListPeriod = 30 * 24 * time.Hour
+
 type BotHandler struct {
-	bot     *tgbotapi.BotAPI
-	repo    *database.Storage
-	auth    *auth.AuthService
-	adminID int64
+	bot  *tgbotapi.BotAPI
+	repo *database.Storage
+	auth *auth.AuthService
+
+	reminderOffsets []time.Duration // за сколько до начала напоминать
+	defaultLocation *time.Location  // пояс для тех, кто не выбрал свой
+	queries         *queryCache     // запросы списков и поиска для кнопок листания
+	settings        *settingsCache  // настройки пользователей
 }
 
-func NewBotHandler(bot *tgbotapi.BotAPI, repo *database.Storage, auth *auth.AuthService, adminID int64) *BotHandler {
+func NewBotHandler(bot *tgbotapi.BotAPI, repo *database.Storage, auth *auth.AuthService, cfg *config.Config) *BotHandler {
 	return &BotHandler{
-		bot:     bot,
-		repo:    repo,
-		auth:    auth,
-		adminID: adminID,
+		bot:             bot,
+		repo:            repo,
+		auth:            auth,
+		reminderOffsets: cfg.ReminderOffsets,
+		defaultLocation: cfg.DefaultTimezone,
+		queries:         newQueryCache(),
+		settings:        newSettingsCache(),
 	}
 }
 
 func (h *BotHandler) HandleUpdate(update tgbotapi.Update) {
-	if update.Message == nil {
+	if update.CallbackQuery != nil {
+		h.handleCallback(update.CallbackQuery)
+		return
+	}
+
+	// Сообщения без отправителя - посты каналов
+	if update.Message == nil || update.Message.From == nil {
 		return
 	}
 
 	msg := update.Message
 	chatID := msg.Chat.ID
 
+	if !h.isForBot(msg) {
+		return
+	}
+
 	// Аутентификация пользователя
 	user, err := h.auth.AuthenticateTelegramUser(
 		msg.From.ID,
@@ -46,204 +66,191 @@ func (h *BotHandler) HandleUpdate(update tgbotapi.Update) {
 	)
 
 	if err != nil {
-		h.sendMessage(chatID, "Ошибка авторизации. Попробуйте позже.")
+		lang, _ := i18n.Match(msg.From.LanguageCode)
+		h.sendMessage(chatID, i18n.Get(lang).T("auth_error"))
 		log.Printf("Auth error: %v", err)
 		return
 	}
+	h.detectLanguage(user.TelegramID, msg.From.LanguageCode)
 
 	// Обработка команд
-	switch {
-	case msg.IsCommand():
+	if msg.IsCommand() {
 		h.handleCommand(msg, user)
-	case strings.HasPrefix(msg.Text, "/create"):
-		h.handleCreateEvent(msg, user)
-	default:
-		h.handleTextMessage(msg, user)
+		return
 	}
+
+	// Файлы с мероприятиями для импорта
+	if msg.Document != nil {
+		h.handleDocument(msg, user)
+		return
+	}
+
+	// Свободный текст сначала получает активный диалог
+	if d := h.getDialog(chatID, user.TelegramID); d != nil {
+		h.handleDialogInput(msg, user, d)
+		return
+	}
+
+	h.handleTextMessage(msg, user)
 }
 
 func (h *BotHandler) handleCommand(msg *tgbotapi.Message, user *models.User) {
 	chatID := msg.Chat.ID
+	lc := h.locale(user.TelegramID)
 
 	switch msg.Command() {
 	case "start":
-		h.sendMessage(chatID, fmt.Sprintf(
-			"Привет, %s!\nЯ бот для планирования мероприятий.\n\n"+
-				"Доступные команды:\n"+
-				"/events - показать все мероприятия\n"+
-				"/create - создать новое мероприятие\n"+
-				"/help - помощь\n\n"+
-				"Для создания мероприятия напишите:\n"+
-				"/create Название|Описание|Дата(YYYY-MM-DD)|Место",
-			user.Name,
-		))
+		h.sendMessage(chatID, render(lc, "start", user))
 
 	case "help":
-		h.sendMessage(chatID,
-			"*Помощь по командам:*\n\n"+
-				"/start - начать работу\n"+
-				"/events - список всех мероприятий\n"+
-				"/myevents - мои мероприятия\n"+
-				"/create - создать мероприятие\n"+
-				"/admin - админ-панель (только для админов)\n"+
-				"/help - эта справка\n\n"+
-				"*Создание мероприятия:*\n"+
-				"Напишите: /create Название|Описание|2024-12-31|Место проведения")
+		h.sendMessage(chatID, render(lc, "help", nil))
 
-	case "events":
-		h.handleShowEvents(chatID)
+	case "create":
+		h.handleCreateEvent(msg, user)
 
-	case "myevents":
-		h.handleMyEvents(chatID, user.TelegramID)
+	case "cancel":
+		h.handleCancel(chatID, user.TelegramID)
 
-	case "admin":
-		h.handleAdminPanel(chatID, user)
+	case "back":
+		h.handleBack(chatID, user.TelegramID)
 
-	default:
-		h.sendMessage(chatID, "Неизвестная команда. Напишите /help для списка команд.")
-	}
-}
+	case "join":
+		h.handleJoin(msg, user)
 
-func (h *BotHandler) handleCreateEvent(msg *tgbotapi.Message, user *models.User) {
-	chatID := msg.Chat.ID
+	case "leave":
+		h.handleLeave(msg, user)
 
-	// Извлекаем данные из сообщения
-	// Формат: /create Название|Описание|Дата|Место
-	parts := strings.SplitN(msg.Text, " ", 2)
-	if len(parts) < 2 {
-		h.sendMessage(chatID, "Неверный формат. Используйте:\n"+
-			"/create Название|Описание|2024-12-31|Место проведения")
-		return
-	}
+	case "edit":
+		h.handleEditEvent(msg, user)
 
-	dataParts := strings.Split(parts[1], "|")
-	if len(dataParts) != 4 {
-		h.sendMessage(chatID, "Неверный формат. Нужно 4 части через |")
-		return
-	}
+	case "timezone":
+		h.handleTimezone(msg, user)
 
-	// Парсим дату
-	date, err := time.Parse("2006-01-02", strings.TrimSpace(dataParts[2]))
-	if err != nil {
-		h.sendMessage(chatID, "Неверный формат даты. Используйте YYYY-MM-DD")
-		return
-	}
+	case "language":
+		h.handleLanguage(msg, user)
 
-	// Создаем мероприятие
-	event := &models.Event{
-		Title:       strings.TrimSpace(dataParts[0]),
-		Description: strings.TrimSpace(dataParts[1]),
-		EventDate:   date,
-		Location:    strings.TrimSpace(dataParts[3]),
-		CreatedBy:   user.TelegramID,
-	}
+	case "settings":
+		h.handleSettings(msg, user)
 
-	if err := h.repo.CreateEvent(event); err != nil {
-		h.sendMessage(chatID, "Ошибка при создании мероприятия")
-		log.Printf("Create event error: %v", err)
-		return
-	}
+	case "cancel_event":
+		h.handleCancelEvent(msg, user)
 
-	h.sendMessage(chatID, fmt.Sprintf(
-		"Мероприятие создано!\n\n"+
-			"*Название:* %s\n"+
-			"*Описание:* %s\n"+
-			"*Дата:* %s\n"+
-			"*Место:* %s",
-		event.Title, event.Description,
-		event.EventDate.Format("02.01.2006"), event.Location,
-	))
-}
+	case "ics":
+		h.handleICS(msg, user)
 
-func (h *BotHandler) handleShowEvents(chatID int64) {
-	events, err := h.repo.GetAllEvents()
-	if err != nil {
-		h.sendMessage(chatID, "Ошибка при получении мероприятий")
-		return
-	}
+	case "ics_all":
+		h.handleICSAll(chatID, user)
 
-	if len(events) == 0 {
-		h.sendMessage(chatID, "Мероприятий пока нет")
-		return
-	}
+	case "events":
+		h.handleShowEvents(msg, user)
 
-	var response strings.Builder
-	response.WriteString("*Все мероприятия:*\n\n")
+	case "myevents":
+		h.handleMyEvents(chatID, user)
 
-	for _, event := range events {
-		response.WriteString(fmt.Sprintf(
-			"• *%s*\n  📍 %s\n  📅 %s\n  👤 Создатель: %d\n\n",
-			event.Title, event.Location,
-			event.EventDate.Format("02.01.2006 15:04"),
-			event.CreatedBy,
-		))
-	}
+	case "search":
+		h.handleSearch(msg, user)
 
-	h.sendMessage(chatID, response.String())
-}
+	case "admin":
+		h.handleAdminPanel(chatID, user)
 
-func (h *BotHandler) handleMyEvents(chatID, userID int64) {
-	// В реальной реализации здесь был бы запрос по created_by
-	// Для простоты показываем все
-	events, err := h.repo.GetAllEvents()
-	if err != nil {
-		h.sendMessage(chatID, "❌ Ошибка при получении мероприятий")
-		return
-	}
+	case "admin_users":
+		h.handleAdminUsers(msg, user)
 
-	var myEvents []models.Event
-	for _, event := range events {
-		if event.CreatedBy == userID {
-			myEvents = append(myEvents, event)
-		}
-	}
+	case "admin_stats":
+		h.handleAdminStats(msg, user)
 
-	if len(myEvents) == 0 {
-		h.sendMessage(chatID, "У вас пока нет мероприятий")
-		return
-	}
+	case "admin_makeadmin":
+		h.handleAdminMakeAdmin(msg, user)
+
+	case "admin_revoke":
+		h.handleAdminRevoke(msg, user)
 
-	var response strings.Builder
-	response.WriteString("*Ваши мероприятия:*\n\n")
+	case "admin_delete_event":
+		h.handleAdminDeleteEvent(msg, user)
 
-	for _, event := range myEvents {
-		response.WriteString(fmt.Sprintf(
-			"• *%s*\n  📍 %s\n  📅 %s\n\n",
-			event.Title, event.Location,
-			event.EventDate.Format("02.01.2006 15:04"),
-		))
+	default:
+		h.sendMessage(chatID, lc.T("unknown_command"))
+	}
+}
+
+// Количество идущих на мероприятия из списка
+func (h *BotHandler) countGoing(events []models.Event) map[int64]int {
+	ids := make([]int64, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
 	}
 
-	h.sendMessage(chatID, response.String())
+	going, err := h.repo.CountGoingByEvents(ids)
+	if err != nil {
+		log.Printf("Count attendees error: %v", err)
+		return map[int64]int{}
+	}
+	return going
 }
 
 func (h *BotHandler) handleAdminPanel(chatID int64, user *models.User) {
 	// Проверка прав админа
-	isAdmin, err := h.auth.IsAdmin(user.TelegramID)
-	if err != nil || !isAdmin {
-		h.sendMessage(chatID, "❌ У вас нет прав администратора")
+	if !h.requireAdmin(chatID, user) {
 		return
 	}
 
-	// Команды админа
-	response := "*Админ-панель*\n\n" +
-		"Доступные команды:\n" +
-		"/admin_users - список пользователей\n" +
-		"/admin_stats - статистика\n" +
-		"/admin_makeadmin ID - назначить админом\n" +
-		"/admin_delete_event ID - удалить мероприятие"
-
-	h.sendMessage(chatID, response)
+	h.sendMessage(chatID, render(h.locale(user.TelegramID), "admin_panel", nil))
 }
 
 func (h *BotHandler) handleTextMessage(msg *tgbotapi.Message, user *models.User) {
 	// Просто эхо-ответ для теста
-	response := fmt.Sprintf("Вы написали: %s\n\nИспользуйте /help для списка команд", msg.Text)
-	h.sendMessage(msg.Chat.ID, response)
+	h.sendMessage(msg.Chat.ID, render(h.locale(user.TelegramID), "echo", msg.Text))
+}
+
+// Отправка с записью ошибки в лог: если Telegram отклонил сообщение,
+// пользователь его не получит, и без лога этого не заметить
+func (h *BotHandler) send(c tgbotapi.Chattable) {
+	if _, err := h.bot.Send(c); err != nil {
+		log.Printf("Send message error: %v", err)
+	}
 }
 
+// Отправка текста в разметке HTML: шаблона из render или строки без спецсимволов
 func (h *BotHandler) sendMessage(chatID int64, text string) {
 	msg := tgbotapi.NewMessage(chatID, text)
-	msg.ParseMode = "Markdown"
-	h.bot.Send(msg)
+	msg.ParseMode = tgbotapi.ModeHTML
+	h.send(msg)
+}
+
+func (h *BotHandler) sendMessageWithKeyboard(chatID int64, text string, keyboard tgbotapi.InlineKeyboardMarkup) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = keyboard
+	h.send(msg)
+}
+
+// Замена текста уже отправленного сообщения (кнопки убираются)
+func (h *BotHandler) editMessage(chatID int64, messageID int, text string) {
+	edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	edit.ParseMode = tgbotapi.ModeHTML
+	h.send(edit)
+}
+
+// Отправка файла; caption - подпись без разметки
+func (h *BotHandler) sendDocument(chatID int64, name string, data []byte, caption string) {
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{Name: name, Bytes: data})
+	doc.Caption = caption
+	if _, err := h.bot.Send(doc); err != nil {
+		log.Printf("Send document error: %v", err)
+	}
+}
+
+// Замена текста и кнопок отправленного сообщения; без keyboard кнопки убираются
+func (h *BotHandler) editMessageWithKeyboard(chatID int64, messageID int, text string, keyboard *tgbotapi.InlineKeyboardMarkup) {
+	if keyboard == nil {
+		h.editMessage(chatID, messageID, text)
+		return
+	}
+	edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, text, *keyboard)
+	edit.ParseMode = tgbotapi.ModeHTML
+	h.send(edit)
+}
+
+func (h *BotHandler) deleteMessage(chatID int64, messageID int) {
+	h.bot.Request(tgbotapi.NewDeleteMessage(chatID, messageID))
 }
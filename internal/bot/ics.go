@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/ical"
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// /ics ID - мероприятие файлом для календаря
+func (h *BotHandler) handleICS(msg *tgbotapi.Message, user *models.User) {
+	lc := h.locale(user.TelegramID)
+	eventID, ok := parseIDArgument(msg)
+	if !ok {
+		h.sendMessage(msg.Chat.ID, lc.T("ics_usage"))
+		return
+	}
+
+	if answer := h.sendEventICS(lc, msg.Chat.ID, user, eventID); answer != "" {
+		h.sendMessage(msg.Chat.ID, escapeHTML(answer))
+	}
+}
+
+// Кнопка «В календарь» в карточке мероприятия
+func (h *BotHandler) handleICSButton(chatID int64, user *models.User, eventID int64) string {
+	return h.sendEventICS(h.locale(user.TelegramID), chatID, user, eventID)
+}
+
+// Отправка .ics с одним мероприятием; у серии - с правилом повторения
+// и пропущенными датами. Возвращает текст ошибки
+func (h *BotHandler) sendEventICS(lc *i18n.Locale, chatID int64, user *models.User, eventID int64) string {
+	event, answer := h.loadEvent(lc, eventID)
+	if event == nil {
+		return answer
+	}
+	if !h.eventVisible(chatID, user, event) {
+		return lc.T("event_not_found")
+	}
+
+	calendar := ical.NewCalendar(event.Title, time.Now())
+	if err := h.addToCalendar(calendar, event); err != nil {
+		log.Printf("Get event exceptions error: %v", err)
+		return lc.T("ics_event_error")
+	}
+
+	h.sendDocument(chatID, fmt.Sprintf("event-%d.ics", event.ID), calendar.Bytes(),
+		lc.T("ics_event_caption"))
+	return ""
+}
+
+// /ics_all - все предстоящие мероприятия чата одним файлом
+func (h *BotHandler) handleICSAll(chatID int64, user *models.User) {
+	lc := h.locale(user.TelegramID)
+	now := time.Now()
+	events, err := h.repo.GetUpcomingEvents(now, chatID)
+	if err != nil {
+		log.Printf("Get upcoming events error: %v", err)
+		h.sendMessage(chatID, lc.T("events_error"))
+		return
+	}
+
+	calendar := ical.NewCalendar(lc.T("ics_calendar_name"), now)
+	count := 0
+	for i := range events {
+		event := &events[i]
+
+		// Серии без оставшихся повторений не выгружаются
+		if event.IsRecurring() {
+			next, err := h.repo.NextOccurrence(event, now)
+			if err != nil {
+				log.Printf("Get occurrence error: %v", err)
+				continue
+			}
+			if next == nil {
+				continue
+			}
+		}
+
+		if err := h.addToCalendar(calendar, event); err != nil {
+			log.Printf("Get event exceptions error: %v", err)
+			h.sendMessage(chatID, lc.T("ics_events_error"))
+			return
+		}
+		count++
+	}
+
+	if count == 0 {
+		h.sendMessage(chatID, lc.T("ics_no_events"))
+		return
+	}
+
+	h.sendDocument(chatID, "events.ics", calendar.Bytes(),
+		lc.T("ics_all_caption", count))
+}
+
+// Добавление мероприятия в календарь вместе с пропущенными повторениями
+func (h *BotHandler) addToCalendar(calendar *ical.Calendar, event *models.Event) error {
+	var exceptions []time.Time
+	if event.IsRecurring() {
+		var err error
+		if exceptions, err = h.repo.GetEventExceptions(event.ID); err != nil {
+			return err
+		}
+	}
+
+	calendar.Add(event, exceptions)
+	return nil
+}
@@ -0,0 +1,253 @@
+package bot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/importer"
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	dialogImportEvents = "import_events"
+
+	maxImportFileSize = 1 << 20 // байт
+	maxImportRows     = 200
+	// Сколько строк файла показывать в предпросмотре, чтобы уложиться в размер сообщения
+	maxImportPreviewLines = 20
+)
+
+// Подтверждение импорта. Мероприятия из файла лежат в d.Data["events"] в JSON,
+// текст предпросмотра - в d.Data["preview"]
+var importEventsWizard = &wizard{
+	steps: []wizardStep{
+		{
+			name: "confirm",
+			prompt: func(d *dialog) string {
+				return d.Data["preview"] + "\n\n" + render(d.locale(), "import_confirm", nil)
+			},
+			apply: func(d *dialog, text string) error {
+				switch strings.ToLower(text) {
+				case "да", "yes", "+":
+					return nil
+				}
+				return userError(d.locale().T("answer_unclear"))
+			},
+		},
+	},
+	finish: finishImportEvents,
+}
+
+func init() {
+	wizards[dialogImportEvents] = importEventsWizard
+}
+
+var importClient = &http.Client{Timeout: 30 * time.Second}
+
+// Присланный документ: .ics или CSV с мероприятиями
+func (h *BotHandler) handleDocument(msg *tgbotapi.Message, user *models.User) {
+	chatID := msg.Chat.ID
+	doc := msg.Document
+	lc := h.locale(user.TelegramID)
+
+	if h.getDialog(chatID, user.TelegramID) != nil {
+		h.sendMessage(chatID, lc.T("import_busy"))
+		return
+	}
+	if doc.FileSize > maxImportFileSize {
+		h.sendMessage(chatID, render(lc, "import_too_big", maxImportFileSize/1024))
+		return
+	}
+
+	data, err := h.downloadFile(doc.FileID)
+	if err != nil {
+		log.Printf("Download file error: %v", err)
+		h.sendMessage(chatID, lc.T("import_download_error"))
+		return
+	}
+
+	loc := h.userLocation(user.TelegramID)
+	rows, err := importer.Parse(doc.FileName, data, loc)
+	if err != nil {
+		h.sendMessage(chatID, escapeHTML(lc.T("import_parse_error", importErrorText(lc, err))))
+		return
+	}
+	if len(rows) == 0 {
+		h.sendMessage(chatID, lc.T("import_empty"))
+		return
+	}
+	if len(rows) > maxImportRows {
+		h.sendMessage(chatID, render(lc, "import_too_many", map[string]int{"Rows": len(rows), "Max": maxImportRows}))
+		return
+	}
+
+	var events []models.ImportedEvent
+	var lines []importLine
+	duplicates, failed := 0, 0
+	seen := make(map[string]bool)
+	now := time.Now()
+
+	for _, row := range rows {
+		event := &row.Event.Event
+		if row.Err == nil {
+			row.Err = validateImportedEvent(lc, event, now)
+		}
+		if row.Err != nil {
+			failed++
+			lines = append(lines, importLine{Line: row.Line, Err: importErrorText(lc, row.Err)})
+			continue
+		}
+
+		key := fmt.Sprintf("%s|%d", event.Title, event.EventDate.Unix())
+		exists, err := h.repo.EventExists(event.Title, event.EventDate, chatID)
+		if err != nil {
+			log.Printf("Check duplicate error: %v", err)
+			h.sendMessage(chatID, lc.T("import_check_error"))
+			return
+		}
+		if exists || seen[key] {
+			duplicates++
+			lines = append(lines, importLine{Line: row.Line, Title: event.Title, Period: formatEventPeriod(lc, event, loc), Duplicate: true})
+			continue
+		}
+		seen[key] = true
+
+		event.CreatedBy = user.TelegramID
+		event.ChatID = chatID
+		events = append(events, row.Event)
+		lines = append(lines, importLine{Line: row.Line, Title: event.Title, Period: formatEventPeriod(lc, event, loc)})
+	}
+
+	more := 0
+	if len(lines) > maxImportPreviewLines {
+		more = len(lines) - maxImportPreviewLines
+		lines = lines[:maxImportPreviewLines]
+	}
+	preview := render(lc, "import_preview", map[string]interface{}{
+		"Total":      len(rows),
+		"Added":      len(events),
+		"Duplicates": duplicates,
+		"Failed":     failed,
+		"Lines":      lines,
+		"More":       more,
+	})
+
+	if len(events) == 0 {
+		h.sendMessage(chatID, preview)
+		return
+	}
+
+	data, err = json.Marshal(events)
+	if err != nil {
+		log.Printf("Marshal import error: %v", err)
+		h.sendMessage(chatID, lc.T("import_read_error"))
+		return
+	}
+	h.startDialog(chatID, user.TelegramID, dialogImportEvents, map[string]string{
+		"events":  string(data),
+		"preview": preview,
+	})
+}
+
+// Строка предпросмотра импорта
+type importLine struct {
+	Line      int
+	Title     string
+	Period    string
+	Err       string
+	Duplicate bool // такое мероприятие уже есть, оно будет пропущено
+}
+
+// Причина ошибки импорта на языке пользователя
+func importErrorText(lc *i18n.Locale, err error) string {
+	var importErr *models.ImportError
+	if !errors.As(err, &importErr) {
+		return err.Error()
+	}
+	key := "import_reason_" + string(importErr.Reason)
+	if importErr.Value == "" {
+		return lc.T(key)
+	}
+	return lc.T(key, importErr.Value)
+}
+
+// Проверка мероприятия из файла по тем же правилам, что и в мастере создания
+func validateImportedEvent(lc *i18n.Locale, event *models.Event, now time.Time) error {
+	switch {
+	case utf8.RuneCountInString(event.Title) > maxTitleLength:
+		return userError(lc.N("import_title_too_long", maxTitleLength))
+	case utf8.RuneCountInString(event.Description) > maxDescriptionLength:
+		return userError(lc.N("import_description_too_long", maxDescriptionLength))
+	case utf8.RuneCountInString(event.Location) > maxLocationLength:
+		return userError(lc.N("import_location_too_long", maxLocationLength))
+	case event.Capacity > 100000:
+		return userError(lc.T("import_capacity_too_many"))
+	case !event.IsRecurring() && !event.EndTime().After(now):
+		return userError(lc.T("import_event_passed"))
+	}
+	return nil
+}
+
+func finishImportEvents(h *BotHandler, msg *tgbotapi.Message, user *models.User, d *dialog) {
+	chatID := msg.Chat.ID
+	lc := d.locale()
+
+	var events []models.ImportedEvent
+	if err := json.Unmarshal([]byte(d.Data["events"]), &events); err != nil {
+		log.Printf("Unmarshal import error: %v", err)
+		h.sendMessage(chatID, lc.T("import_error"))
+		return
+	}
+
+	if err := h.repo.ImportEvents(events); err != nil {
+		log.Printf("Import events error: %v", err)
+		h.sendMessage(chatID, lc.T("import_failed"))
+		return
+	}
+
+	log.Printf("Пользователь %d импортировал %d мероприятий", user.TelegramID, len(events))
+	h.sendMessage(chatID, render(lc, "import_done", len(events)))
+}
+
+// Скачивание файла, присланного боту
+func (h *BotHandler) downloadFile(fileID string) ([]byte, error) {
+	link, err := h.bot.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := importClient.Get(link)
+	if err != nil {
+		// В адресе файла есть токен бота, в лог он попасть не должен
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("скачивание файла: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImportFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxImportFileSize {
+		return nil, fmt.Errorf("файл больше %d байт", maxImportFileSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,102 @@
+package bot
+
+import (
+	"log"
+	"strings"
+
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Язык пользователя; если он не выбран и не определён - язык по умолчанию
+func (h *BotHandler) userLanguage(userID int64) string {
+	settings, err := h.userSettings(userID)
+	if err != nil {
+		log.Printf("Get user settings error: %v", err)
+		return i18n.DefaultLanguage
+	}
+	if settings.Language == "" {
+		return i18n.DefaultLanguage
+	}
+	return settings.Language
+}
+
+// Каталог сообщений на языке пользователя
+func (h *BotHandler) locale(userID int64) *i18n.Locale {
+	return i18n.Get(h.userLanguage(userID))
+}
+
+// Язык из Telegram запоминается при первом обращении, чтобы на нём же
+// приходили уведомления. Выбранный через /language язык не меняется
+func (h *BotHandler) detectLanguage(userID int64, code string) {
+	lang, ok := i18n.Match(code)
+	if !ok {
+		return
+	}
+
+	err := h.updateUserSettings(userID, func(settings *models.UserSettings) bool {
+		if settings.Language != "" {
+			return false
+		}
+		settings.Language = lang
+		return true
+	})
+	if err != nil {
+		log.Printf("Save user settings error: %v", err)
+	}
+}
+
+// /language [код] - показать или изменить язык
+func (h *BotHandler) handleLanguage(msg *tgbotapi.Message, user *models.User) {
+	if arg := strings.TrimSpace(msg.CommandArguments()); arg != "" {
+		h.sendMessage(msg.Chat.ID, escapeHTML(h.setLanguage(user, arg)))
+		return
+	}
+	h.sendLanguageMenu(msg.Chat.ID, user)
+}
+
+// Текущий язык и кнопки выбора
+func (h *BotHandler) sendLanguageMenu(chatID int64, user *models.User) {
+	lc := h.locale(user.TelegramID)
+
+	var row []tgbotapi.InlineKeyboardButton
+	for i, lang := range i18n.Languages {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(i18n.Get(lang).Name,
+			encodeCallback(actionLanguage, int64(i))))
+	}
+
+	h.sendMessageWithKeyboard(chatID, render(lc, "language", map[string]interface{}{
+		"Name":      lc.Name,
+		"Languages": i18n.Languages,
+	}), tgbotapi.NewInlineKeyboardMarkup(row))
+}
+
+// Кнопка выбора языка
+func (h *BotHandler) handleLanguageButton(user *models.User, idx int64) string {
+	if idx < 0 || idx >= int64(len(i18n.Languages)) {
+		return h.locale(user.TelegramID).T("language_unknown")
+	}
+	return h.setLanguage(user, i18n.Languages[idx])
+}
+
+// Сохранение языка; возвращает текст для пользователя уже на новом языке
+func (h *BotHandler) setLanguage(user *models.User, code string) string {
+	lang, ok := i18n.Match(code)
+	if !ok {
+		return h.locale(user.TelegramID).T("language_unknown")
+	}
+	lc := i18n.Get(lang)
+
+	err := h.updateUserSettings(user.TelegramID, func(settings *models.UserSettings) bool {
+		settings.Language = lang
+		return true
+	})
+	if err != nil {
+		log.Printf("Save user settings error: %v", err)
+		return lc.T("settings_save_error")
+	}
+
+	return lc.T("language_set", lc.Name)
+}
@@ -0,0 +1,331 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-planner-bot/internal/database"
+	"event-planner-bot/internal/dateparse"
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// Самый длинный промежуток, который можно запросить в /events
+	maxEventsListRange = 366 * 24 * time.Hour
+	// Мероприятий на странице списка: с длинными названиями и местами
+	// страница должна уложиться в 4096 символов сообщения
+	eventsPageSize = 8
+)
+
+// Разобранные аргументы /events
+type eventsQuery struct {
+	filter  database.EventFilter
+	period  string // «на сегодня», «с 01.12.2026 по 15.12.2026»
+	creator string // «, созданные вами»; пусто - все
+}
+
+// Разделители дат в промежутке «01.12.2026 - 15.12.2026»
+var dateRangeSeparators = []string{"..", "—", "–", " - ", " по ", " to ", " until "}
+
+// Разбор аргументов /events: today, tomorrow, week, month, past, промежуток дат,
+// mine или @username. Без аргументов - ближайшие 30 дней.
+// В группе chatID показываются только мероприятия этой группы
+func (h *BotHandler) parseEventsQuery(args string, chatID int64, user *models.User, now time.Time) (*eventsQuery, error) {
+	loc := h.userLocation(user.TelegramID)
+	lc := h.locale(user.TelegramID)
+	today := startOfDay(now, loc)
+
+	q := &eventsQuery{
+		filter: database.EventFilter{From: now, To: now.Add(eventsListPeriod), ChatID: chatID},
+		period: lc.T("period_month"),
+	}
+
+	var rest []string
+	words := strings.Fields(args)
+	for i := 0; i < len(words); i++ {
+		word := strings.ToLower(words[i])
+		switch word {
+		case "today", "сегодня":
+			q.filter.From, q.filter.To = today, today.AddDate(0, 0, 1)
+			q.period = lc.T("period_today")
+		case "tomorrow", "завтра":
+			q.filter.From, q.filter.To = today.AddDate(0, 0, 1), today.AddDate(0, 0, 2)
+			q.period = lc.T("period_tomorrow")
+		case "week", "неделя", "неделю":
+			q.filter.From, q.filter.To = now, now.AddDate(0, 0, 7)
+			q.period = lc.T("period_week")
+		case "month", "месяц":
+			q.filter.From, q.filter.To = now, now.Add(eventsListPeriod)
+			q.period = lc.T("period_month")
+		case "past", "прошедшие", "прошлые":
+			q.filter.From, q.filter.To = now.Add(-eventsListPeriod), now
+			q.filter.EndedBy = now
+			q.filter.Newest = true
+			q.period = lc.T("period_past")
+		case "mine", "my", "мои":
+			q.filter.CreatedBy = user.TelegramID
+			q.creator = lc.T("creator_me")
+		case "by", "от", "автор":
+			if i+1 == len(words) {
+				return nil, userError(lc.T("creator_required"))
+			}
+			i++
+			if err := h.setEventsCreator(lc, q, words[i]); err != nil {
+				return nil, err
+			}
+		default:
+			if strings.HasPrefix(word, "@") {
+				if err := h.setEventsCreator(lc, q, word); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			rest = append(rest, words[i])
+		}
+	}
+
+	if len(rest) > 0 {
+		from, to, err := parseDateRange(lc, strings.Join(rest, " "), now, loc)
+		if err != nil {
+			return nil, err
+		}
+		q.filter.From, q.filter.To = from, to
+		q.filter.EndedBy = time.Time{}
+		q.filter.Newest = false
+
+		last := to.AddDate(0, 0, -1)
+		if last.Equal(from) {
+			q.period = lc.T("period_day", lc.Date(from))
+		} else {
+			q.period = lc.T("period_range", lc.Date(from), lc.Date(last))
+		}
+	}
+	return q, nil
+}
+
+// Фильтр по создателю: @username или Telegram ID
+func (h *BotHandler) setEventsCreator(lc *i18n.Locale, q *eventsQuery, value string) error {
+	if id, err := strconv.ParseInt(value, 10, 64); err == nil {
+		q.filter.CreatedBy = id
+		q.creator = lc.T("creator_id", id)
+		return nil
+	}
+
+	username := strings.TrimPrefix(value, "@")
+	creator, err := h.repo.GetUserByUsername(username)
+	if err != nil {
+		log.Printf("Get user error: %v", err)
+		return userError(lc.T("creator_search_error"))
+	}
+	if creator == nil {
+		return userError(lc.T("creator_not_found", username))
+	}
+	q.filter.CreatedBy = creator.TelegramID
+	q.creator = lc.T("creator_username", creator.Username)
+	return nil
+}
+
+// Промежуток дат: одна дата или две через разделитель. Возвращает начало
+// первого дня и начало дня после последнего
+func parseDateRange(lc *i18n.Locale, text string, now time.Time, loc *time.Location) (time.Time, time.Time, error) {
+	lower := strings.ToLower(strings.TrimSpace(text))
+	for _, prefix := range []string{"с ", "from "} {
+		lower = strings.TrimPrefix(lower, prefix)
+	}
+
+	if day, err := parseDay(lower, now, loc); err == nil {
+		return day, day.AddDate(0, 0, 1), nil
+	}
+
+	// Сначала явные разделители, потом любой дефис: «2026-12-01-2026-12-15»
+	var candidates [][2]string
+	for _, sep := range dateRangeSeparators {
+		if left, right, ok := strings.Cut(lower, sep); ok {
+			candidates = append(candidates, [2]string{left, right})
+		}
+	}
+	for i := 0; i < len(lower); i++ {
+		if lower[i] == '-' {
+			candidates = append(candidates, [2]string{lower[:i], lower[i+1:]})
+		}
+	}
+
+	for _, c := range candidates {
+		from, err := parseDay(c[0], now, loc)
+		if err != nil {
+			continue
+		}
+		to, err := parseDay(c[1], now, loc)
+		if err != nil {
+			continue
+		}
+		if to.Before(from) {
+			return time.Time{}, time.Time{}, userError(lc.T("range_reversed"))
+		}
+		to = to.AddDate(0, 0, 1)
+		if to.Sub(from) > maxEventsListRange {
+			return time.Time{}, time.Time{}, userError(lc.T("range_too_long"))
+		}
+		return from, to, nil
+	}
+
+	return time.Time{}, time.Time{}, userError(lc.T("events_filter_unclear"))
+}
+
+// Начало дня, заданного датой без времени
+func parseDay(text string, now time.Time, loc *time.Location) (time.Time, error) {
+	parsed, err := dateparse.Parse(strings.TrimSpace(text), now, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !parsed.HasDate || parsed.HasTime {
+		return time.Time{}, dateparse.ErrUnrecognized
+	}
+	return startOfDay(parsed.Time, loc), nil
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// /events [фильтр] - список мероприятий
+func (h *BotHandler) handleShowEvents(msg *tgbotapi.Message, user *models.User) {
+	h.sendEventsList(msg.Chat.ID, user, msg.CommandArguments())
+}
+
+// /myevents - мероприятия пользователя на ближайшие 30 дней
+func (h *BotHandler) handleMyEvents(chatID int64, user *models.User) {
+	h.sendEventsList(chatID, user, "mine")
+}
+
+// Строка списка или результатов поиска для шаблона event_item
+type eventItem struct {
+	Num         int
+	Event       *models.Event
+	Shown       *models.Event // у серии - показываемое повторение
+	Loc         *time.Location
+	Going       int
+	ShowGoing   bool
+	ShowCreator bool
+}
+
+// Первая страница списка по аргументам /events
+func (h *BotHandler) sendEventsList(chatID int64, user *models.User, args string) {
+	q, err := h.parseEventsQuery(args, chatID, user, time.Now())
+	if err != nil {
+		h.sendMessage(chatID, escapeHTML(err.Error()))
+		return
+	}
+
+	text, keyboard, err := h.eventsPage(user, h.queries.add(args), q, nil, false, 1)
+	if err != nil {
+		log.Printf("Find events error: %v", err)
+		h.sendMessage(chatID, h.locale(user.TelegramID).T("events_error"))
+		return
+	}
+	if keyboard == nil {
+		h.sendMessage(chatID, text)
+		return
+	}
+	h.sendMessageWithKeyboard(chatID, text, *keyboard)
+}
+
+// Кнопка листания списка: страница page после (или перед, если backward)
+// мероприятия, которое начинается в cursorNano и имеет ID cursorID
+func (h *BotHandler) handleEventsPageButton(msg *tgbotapi.Message, user *models.User, queryID, page, backward, cursorNano, cursorID int64) string {
+	args, ok := h.queries.get(queryID)
+	if !ok {
+		return h.locale(user.TelegramID).T("events_list_expired")
+	}
+
+	q, err := h.parseEventsQuery(args, msg.Chat.ID, user, time.Now())
+	if err != nil {
+		return err.Error()
+	}
+
+	cursor := &database.EventCursor{Date: time.Unix(0, cursorNano).UTC(), ID: cursorID}
+	text, keyboard, err := h.eventsPage(user, queryID, q, cursor, backward == 1, int(page))
+	if err != nil {
+		log.Printf("Find events error: %v", err)
+		return h.locale(user.TelegramID).T("events_error")
+	}
+	h.editMessageWithKeyboard(msg.Chat.ID, msg.MessageID, text, keyboard)
+	return ""
+}
+
+// Страница списка (с единицы) и кнопки под ней
+func (h *BotHandler) eventsPage(user *models.User, queryID int64, q *eventsQuery, cursor *database.EventCursor, backward bool, page int) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	events, more, err := h.repo.FindEventsPage(q.filter, cursor, backward, eventsPageSize)
+	if err != nil {
+		return "", nil, err
+	}
+
+	lc := h.locale(user.TelegramID)
+	if len(events) == 0 {
+		if cursor != nil {
+			return lc.T("events_list_changed"), nil, nil
+		}
+		return render(lc, "events_empty", map[string]string{"Period": q.period, "Creator": q.creator}), nil, nil
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	hasPrev, hasNext := page > 1, more
+	if backward {
+		hasPrev, hasNext = more, true
+	}
+
+	going := h.countGoing(events)
+	loc := h.userLocation(user.TelegramID)
+
+	var items []eventItem
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i := range events {
+		event := &events[i]
+		num := (page-1)*eventsPageSize + i + 1
+		items = append(items, eventItem{
+			Num:         num,
+			Event:       event,
+			Shown:       event,
+			Loc:         loc,
+			Going:       going[event.ID],
+			ShowGoing:   true,
+			ShowCreator: q.filter.CreatedBy == 0,
+		})
+		rows = append(rows, eventButtons(event, fmt.Sprintf(" %d", num)))
+	}
+
+	text := render(lc, "events_list", map[string]interface{}{
+		"Period":  q.period,
+		"Creator": q.creator,
+		"Paged":   hasPrev || hasNext,
+		"Page":    page,
+		"Items":   items,
+	})
+
+	var nav []tgbotapi.InlineKeyboardButton
+	if hasPrev {
+		first := events[0]
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(lc.T("button_prev"),
+			encodeCallback(actionEventsPage, queryID, int64(page-1), 1, first.EventDate.UnixNano(), first.ID)))
+	}
+	if hasNext {
+		last := events[len(events)-1]
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(lc.T("button_next"),
+			encodeCallback(actionEventsPage, queryID, int64(page+1), 0, last.EventDate.UnixNano(), last.ID)))
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return text, &keyboard, nil
+}
@@ -0,0 +1,91 @@
+package bot
+
+import (
+	"log"
+	"time"
+
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+)
+
+// Участники мероприятия, которым нужно сообщить об изменениях
+func (h *BotHandler) eventSubscribers(eventID int64) []int64 {
+	subscribers, err := h.repo.GetEventSubscribers(eventID)
+	if err != nil {
+		log.Printf("Get event subscribers error: %v", err)
+	}
+	return subscribers
+}
+
+// Рассылка всем участникам, кроме того, кто внёс изменение.
+// Текст строится для каждого получателя на его языке и в его часовом поясе
+func (h *BotHandler) notifySubscribers(subscribers []int64, actorID int64, text func(lc *i18n.Locale, loc *time.Location) string) {
+	for _, userID := range subscribers {
+		if userID == actorID {
+			continue
+		}
+		h.sendMessage(userID, text(h.locale(userID), h.userLocation(userID)))
+	}
+}
+
+// Данные для шаблона уведомления о мероприятии в поясе получателя
+func eventNotice(event *models.Event, loc *time.Location) map[string]interface{} {
+	return map[string]interface{}{"Event": event, "Loc": loc}
+}
+
+// Сообщение об изменении мероприятия: «дата: 12.05 → 14.05»
+func (h *BotHandler) notifyEventChanged(old, updated *models.Event, actorID int64) {
+	if len(models.ChangedFields(old, updated, h.defaultLocation)) == 0 &&
+		old.EndTime().Equal(updated.EndTime()) && old.Recurrence == updated.Recurrence {
+		return
+	}
+
+	h.notifySubscribers(h.eventSubscribers(updated.ID), actorID, func(lc *i18n.Locale, loc *time.Location) string {
+		changes := formatChanges(lc, old, updated, loc)
+		if old.Recurrence != updated.Recurrence {
+			changes = append(changes, lc.T("change_recurrence", describeRecurrence(lc, old.Recurrence), describeRecurrence(lc, updated.Recurrence)))
+		}
+		if len(changes) == 0 {
+			// Изменилось только время окончания
+			changes = []string{lc.T("change_time", formatEventPeriod(lc, old, loc), formatEventPeriod(lc, updated, loc))}
+		}
+		data := eventNotice(updated, loc)
+		data["Changes"] = changes
+		return render(lc, "notify_changed", data)
+	})
+}
+
+// Сообщение об отмене мероприятия
+func (h *BotHandler) notifyEventCancelled(event *models.Event, actorID int64) {
+	h.notifySubscribers(h.eventSubscribers(event.ID), actorID, func(lc *i18n.Locale, loc *time.Location) string {
+		return render(lc, "notify_cancelled", eventNotice(event, loc))
+	})
+}
+
+// Сообщение об удалении мероприятия. Участников нужно получить
+// до удаления, вместе с мероприятием удаляются и ответы на него
+func (h *BotHandler) notifyEventDeleted(event *models.Event, subscribers []int64, actorID int64) {
+	h.notifySubscribers(subscribers, actorID, func(lc *i18n.Locale, loc *time.Location) string {
+		return render(lc, "notify_deleted", eventNotice(event, loc))
+	})
+}
+
+// Строки «что изменилось» для уведомления, без разметки
+func formatChanges(lc *i18n.Locale, old, updated *models.Event, loc *time.Location) []string {
+	oldStart, newStart := old.EventDate.In(loc), updated.EventDate.In(loc)
+
+	var lines []string
+	for _, field := range models.ChangedFields(old, updated, loc) {
+		switch field {
+		case models.FieldTitle:
+			lines = append(lines, lc.T("change_title", old.Title, updated.Title))
+		case models.FieldDate:
+			lines = append(lines, lc.T("change_date", lc.ShortDate(oldStart), lc.ShortDate(newStart)))
+		case models.FieldTime:
+			lines = append(lines, lc.T("change_time", lc.Clock(oldStart), lc.Clock(newStart)))
+		case models.FieldLocation:
+			lines = append(lines, lc.T("change_location", old.Location, updated.Location))
+		}
+	}
+	return lines
+}
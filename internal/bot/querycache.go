@@ -0,0 +1,47 @@
+package bot
+
+import (
+	"sync"
+	"time"
+)
+
+// Сколько последних запросов помнить для кнопок листания
+const maxCachedQueries = 1000
+
+// Запросы списков и поиска по номерам. Текст запроса не помещается
+// в callback-data, поэтому в кнопках листания передаётся только номер.
+// Номера начинаются с момента запуска в микросекундах: после перезапуска
+// бота старые кнопки не находят запрос и перестают работать, а не
+// открывают чужой запрос с тем же номером
+type queryCache struct {
+	mu      sync.Mutex
+	next    int64
+	queries map[int64]string
+	order   []int64 // номера в порядке добавления, для вытеснения старых
+}
+
+func newQueryCache() *queryCache {
+	return &queryCache{next: time.Now().UnixMicro(), queries: make(map[int64]string)}
+}
+
+func (c *queryCache) add(query string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.next++
+	c.queries[c.next] = query
+	c.order = append(c.order, c.next)
+	if len(c.order) > maxCachedQueries {
+		delete(c.queries, c.order[0])
+		c.order = c.order[1:]
+	}
+	return c.next
+}
+
+func (c *queryCache) get(id int64) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	query, ok := c.queries[id]
+	return query, ok
+}
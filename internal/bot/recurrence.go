@@ -0,0 +1,206 @@
+package bot
+
+import (
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+	"event-planner-bot/internal/recurrence"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Что менять в повторяющемся мероприятии
+const (
+	editScopeOccurrence = iota // только одно повторение
+	editScopeSeries            // всю серию
+	editScopeSkip              // пропустить повторение
+)
+
+// «каждые 2 недели», «every 3 days»
+var everyPattern = regexp.MustCompile(`^(?:каждые|каждых|каждый|каждую|every)\s+(\d+)\s+(\S+)$`)
+
+var repeatUnits = map[string]recurrence.Frequency{
+	"день": recurrence.Daily, "дня": recurrence.Daily, "дней": recurrence.Daily,
+	"day": recurrence.Daily, "days": recurrence.Daily,
+	"неделю": recurrence.Weekly, "недели": recurrence.Weekly, "недель": recurrence.Weekly,
+	"week": recurrence.Weekly, "weeks": recurrence.Weekly,
+	"месяц": recurrence.Monthly, "месяца": recurrence.Monthly, "месяцев": recurrence.Monthly,
+	"month": recurrence.Monthly, "months": recurrence.Monthly,
+}
+
+// Разбор ответа на вопрос о повторении. Пустая строка - не повторять
+func parseRepeat(lc *i18n.Locale, text string) (string, error) {
+	text = strings.ToLower(strings.Join(strings.Fields(text), " "))
+
+	var rule *recurrence.Rule
+	switch text {
+	case "-", "нет", "no", "не повторять":
+		return "", nil
+	case "каждый день", "ежедневно", "daily", "every day":
+		rule = &recurrence.Rule{Freq: recurrence.Daily, Interval: 1}
+	case "каждую неделю", "еженедельно", "weekly", "every week":
+		rule = &recurrence.Rule{Freq: recurrence.Weekly, Interval: 1}
+	case "каждый месяц", "ежемесячно", "monthly", "every month":
+		rule = &recurrence.Rule{Freq: recurrence.Monthly, Interval: 1}
+	default:
+		if m := everyPattern.FindStringSubmatch(text); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			freq, ok := repeatUnits[m[2]]
+			if ok && n >= 1 {
+				rule = &recurrence.Rule{Freq: freq, Interval: n}
+			}
+		}
+	}
+
+	if rule == nil {
+		var err error
+		if rule, err = recurrence.Parse(text); err != nil {
+			return "", userError(lc.T("repeat_invalid", err))
+		}
+	}
+	return rule.String(), nil
+}
+
+// Правило повторения словами: «каждую неделю (пн, ср), 10 раз»
+func describeRecurrence(lc *i18n.Locale, rrule string) string {
+	if rrule == "" {
+		return lc.T("repeat_none")
+	}
+	rule, err := recurrence.Parse(rrule)
+	if err != nil {
+		return rrule
+	}
+
+	unit := map[recurrence.Frequency]string{
+		recurrence.Daily:   "day",
+		recurrence.Weekly:  "week",
+		recurrence.Monthly: "month",
+	}[rule.Freq]
+
+	var b strings.Builder
+	if rule.Interval > 1 {
+		b.WriteString(lc.N("repeat_every_n_"+unit, rule.Interval))
+	} else {
+		b.WriteString(lc.T("repeat_every_" + unit))
+	}
+
+	if len(rule.ByDay) > 0 {
+		days := make([]string, len(rule.ByDay))
+		for i, day := range rule.ByDay {
+			name := lc.Weekday(day.Day)
+			switch {
+			case day.N == -1:
+				days[i] = lc.T("repeat_last_weekday", name)
+			case day.N != 0:
+				days[i] = lc.T("repeat_nth_weekday", day.N, name)
+			default:
+				days[i] = name
+			}
+		}
+		b.WriteString(" (" + strings.Join(days, ", ") + ")")
+	}
+
+	switch {
+	case rule.Count > 0:
+		b.WriteString(", " + lc.N("repeat_count", rule.Count))
+	case !rule.Until.IsZero():
+		b.WriteString(", " + lc.T("repeat_until", lc.Date(rule.Until)))
+	}
+	return b.String()
+}
+
+// Вопрос, что менять в повторяющемся мероприятии: одно повторение или всю серию.
+// occurrence - начало повторения в Unix-времени, 0 - ближайшее
+func (h *BotHandler) askEditScope(chatID int64, user *models.User, event *models.Event, occurrence int64) string {
+	lc := h.locale(user.TelegramID)
+
+	var current *models.Event
+	var err error
+	if occurrence != 0 {
+		current, err = h.repo.GetOccurrence(event, time.Unix(occurrence, 0))
+	} else {
+		current, err = h.repo.NextOccurrence(event, time.Now())
+	}
+	if err != nil {
+		log.Printf("Get occurrence error: %v", err)
+		return lc.T("event_get_error")
+	}
+	if current == nil {
+		// Повторений не осталось - меняем серию целиком
+		h.startDialog(chatID, user.TelegramID, dialogEditEvent, eventDialogData(event, h.userLocation(user.TelegramID)))
+		return ""
+	}
+
+	when := formatEventTime(lc, current.EventDate, h.userLocation(user.TelegramID))
+	start := current.EventDate.Unix()
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lc.T("button_scope_occurrence", when),
+				encodeCallback(actionEditScope, event.ID, start, editScopeOccurrence)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lc.T("button_scope_series"),
+				encodeCallback(actionEditScope, event.ID, start, editScopeSeries)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lc.T("button_scope_skip", when),
+				encodeCallback(actionEditScope, event.ID, start, editScopeSkip)),
+		),
+	)
+	h.sendMessageWithKeyboard(chatID, render(lc, "edit_scope", event), keyboard)
+	return ""
+}
+
+// Кнопка выбора: изменить повторение, всю серию или пропустить повторение
+func (h *BotHandler) handleEditScope(chatID int64, user *models.User, eventID, occurrence, scope int64) string {
+	lc := h.locale(user.TelegramID)
+	event, answer := h.loadEvent(lc, eventID)
+	if event == nil {
+		return answer
+	}
+	if !h.canManageEvent(user, event) {
+		return forbiddenText(lc, event, "edit_forbidden")
+	}
+
+	loc := h.userLocation(user.TelegramID)
+	if scope == editScopeSeries || !event.IsRecurring() {
+		h.startDialog(chatID, user.TelegramID, dialogEditEvent, eventDialogData(event, loc))
+		return ""
+	}
+
+	current, err := h.repo.GetOccurrence(event, time.Unix(occurrence, 0))
+	if err != nil {
+		log.Printf("Get occurrence error: %v", err)
+		return lc.T("event_get_error")
+	}
+	if current == nil {
+		return lc.T("occurrence_gone")
+	}
+
+	switch scope {
+	case editScopeOccurrence:
+		data := eventDialogData(current, loc)
+		data["occurrence"] = strconv.FormatInt(occurrence, 10)
+		h.startDialog(chatID, user.TelegramID, dialogEditEvent, data)
+		return ""
+
+	case editScopeSkip:
+		subscribers := h.eventSubscribers(event.ID)
+		if err := h.repo.SkipOccurrence(event.ID, current.EventDate); err != nil {
+			log.Printf("Skip occurrence error: %v", err)
+			return lc.T("event_save_error")
+		}
+
+		log.Printf("Пользователь %d пропустил повторение %d мероприятия %d", user.TelegramID, occurrence, event.ID)
+		h.notifySubscribers(subscribers, user.TelegramID, func(lc *i18n.Locale, loc *time.Location) string {
+			return render(lc, "notify_skipped", eventNotice(current, loc))
+		})
+		return lc.T("occurrence_skipped")
+	}
+	return lc.T("unknown_action")
+}
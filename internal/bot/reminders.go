@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"log"
+	"time"
+
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+)
+
+// Рассылка напоминаний о ближайших мероприятиях. Вызывается планировщиком.
+// Сроки у каждого участника свои, поэтому мероприятия выбираются
+// на самый ранний срок из возможных
+func (h *BotHandler) SendReminders(now time.Time) {
+	window := h.maxReminderOffset()
+	if window == 0 {
+		return
+	}
+
+	events, err := h.repo.GetEventsStartingBetween(now, now.Add(window))
+	if err != nil {
+		log.Printf("Get upcoming events error: %v", err)
+		return
+	}
+
+	for i := range events {
+		h.remind(&events[i], now)
+	}
+}
+
+// Отправка напоминания участникам мероприятия, у которых наступил срок.
+// Выбирается наименьший из наступивших сроков: если бот был выключен
+// и пропустил «за день», придёт только «за час»
+func (h *BotHandler) remind(event *models.Event, now time.Time) {
+	recipients, err := h.repo.GetReminderRecipients(event.ID)
+	if err != nil {
+		log.Printf("Get reminder recipients error: %v", err)
+		return
+	}
+
+	for _, userID := range recipients {
+		offset := dueReminderOffset(h.userReminderOffsets(userID), event.EventDate, now)
+		if offset == 0 {
+			continue
+		}
+
+		claimed, err := h.repo.ClaimReminder(event.ID, event.EventDate, userID, offset)
+		if err != nil {
+			log.Printf("Claim reminder error: %v", err)
+			continue
+		}
+		if claimed {
+			lc := h.locale(userID)
+			data := eventNotice(event, h.userLocation(userID))
+			data["Offset"] = formatOffset(lc, offset)
+			h.sendMessage(userID, render(lc, "reminder", data))
+		}
+	}
+}
+
+// Наименьший из наступивших сроков напоминания о мероприятии,
+// начинающемся в start; 0 - ни один срок не наступил
+func dueReminderOffset(offsets []time.Duration, start, now time.Time) time.Duration {
+	var due time.Duration
+	for _, o := range offsets {
+		if !start.Add(-o).After(now) && (due == 0 || o < due) {
+			due = o
+		}
+	}
+	return due
+}
+
+// Срок напоминания словами: «1 день», «2 часа», «30 минут»
+func formatOffset(lc *i18n.Locale, d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+		return lc.N("offset_days", int(d/(24*time.Hour)))
+	case d >= time.Hour && d%time.Hour == 0:
+		return lc.N("offset_hours", int(d/time.Hour))
+	default:
+		return lc.N("offset_minutes", int(d/time.Minute))
+	}
+}
@@ -0,0 +1,65 @@
+package bot
+
+import (
+	"embed"
+	"html"
+	"html/template"
+	"log"
+	"strings"
+	"time"
+
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+)
+
+// Шаблоны ответов бота, свой набор на каждый язык: templates/<язык>/*.tmpl.
+// Сообщения отправляются в режиме HTML, а html/template сам экранирует
+// подставленные в шаблон названия, описания и имена
+//
+//go:embed templates
+var templateFiles embed.FS
+
+var templates = map[string]*template.Template{}
+
+func init() {
+	for _, lang := range i18n.Languages {
+		lc := i18n.Get(lang)
+		templates[lang] = template.Must(template.New("").
+			Funcs(templateFuncs(lc)).
+			ParseFS(templateFiles, "templates/"+lang+"/*.tmpl"))
+	}
+}
+
+// Функции шаблонов; даты и подписи - на языке набора
+func templateFuncs(lc *i18n.Locale) template.FuncMap {
+	return template.FuncMap{
+		"t":             lc.T,
+		"n":             lc.N,
+		"date":          lc.Date,
+		"period":        func(e *models.Event, loc *time.Location) string { return formatEventPeriod(lc, e, loc) },
+		"status":        func(s models.EventStatus) string { return statusLabel(lc, s) },
+		"statusMark":    func(s models.EventStatus) string { return statusMark(lc, s) },
+		"recurringMark": recurringMark,
+		"recurrence":    func(rrule string) string { return describeRecurrence(lc, rrule) },
+		"capacity":      func(n int) string { return formatCapacity(lc, n) },
+		"going":         formatGoing,
+		"join":          strings.Join,
+		"inc":           func(i int) int { return i + 1 },
+	}
+}
+
+// Текст сообщения по шаблону name на языке lc
+func render(lc *i18n.Locale, name string, data interface{}) string {
+	var b strings.Builder
+	if err := templates[lc.Lang].ExecuteTemplate(&b, name, data); err != nil {
+		log.Printf("Render template %s/%s error: %v", lc.Lang, name, err)
+		return lc.T("render_error")
+	}
+	return b.String()
+}
+
+// Экранирование текста, который отправляется без шаблона:
+// ошибки проверки ввода и ответы, общие с всплывающими подсказками
+func escapeHTML(text string) string {
+	return html.EscapeString(text)
+}
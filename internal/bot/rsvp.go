@@ -0,0 +1,180 @@
+package bot
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Подпись статуса участия: «✅ Иду»
+func attendanceLabel(lc *i18n.Locale, status models.AttendanceStatus) string {
+	return lc.T("attendance_" + string(status))
+}
+
+// Запись ответа пользователя из чата chatID; возвращает текст для пользователя
+func (h *BotHandler) setAttendance(chatID int64, user *models.User, eventID int64, status models.AttendanceStatus) string {
+	lc := h.locale(user.TelegramID)
+	event, answer := h.loadEvent(lc, eventID)
+	if event == nil {
+		return answer
+	}
+	if !h.eventVisible(chatID, user, event) {
+		return lc.T("event_not_found")
+	}
+	if event.Status == models.StatusCancelled {
+		return lc.T("event_cancelled")
+	}
+	if event.Status == models.StatusEnded || !event.IsRecurring() && event.EventDate.Before(time.Now()) {
+		return lc.T("event_passed")
+	}
+	// На серию записываются целиком, пока остаются будущие повторения
+	if event.IsRecurring() {
+		next, err := h.repo.NextOccurrence(event, time.Now())
+		if err != nil {
+			log.Printf("Get occurrence error: %v", err)
+		} else if next == nil {
+			return lc.T("series_passed")
+		}
+	}
+
+	change, err := h.repo.ChangeAttendance(event.ID, user.TelegramID, status)
+	if err != nil {
+		log.Printf("Change attendance error: %v", err)
+		return lc.T("attendance_error")
+	}
+
+	h.notifyPromoted(event, change.Promoted)
+
+	switch change.Status {
+	case models.AttendanceGoing:
+		return lc.T("rsvp_going", event.Title)
+	case models.AttendanceWaitlist:
+		return lc.T("rsvp_waitlist", event.Title)
+	case models.AttendanceMaybe:
+		return lc.T("rsvp_maybe", event.Title)
+	default:
+		return lc.T("rsvp_not_going", event.Title)
+	}
+}
+
+// Уведомление тех, кого перевели из очереди в участники
+func (h *BotHandler) notifyPromoted(event *models.Event, userIDs []int64) {
+	for _, userID := range userIDs {
+		lc := h.locale(userID)
+		h.sendMessage(userID, render(lc, "notify_promoted", eventNotice(event, h.userLocation(userID))))
+	}
+}
+
+// /join ID [maybe] - записаться на мероприятие
+func (h *BotHandler) handleJoin(msg *tgbotapi.Message, user *models.User) {
+	args := strings.Fields(msg.CommandArguments())
+	lc := h.locale(user.TelegramID)
+
+	var eventID int64
+	var err error
+	if len(args) > 0 {
+		eventID, err = strconv.ParseInt(args[0], 10, 64)
+	}
+	if len(args) == 0 || len(args) > 2 || err != nil {
+		h.sendMessage(msg.Chat.ID, lc.T("join_usage"))
+		return
+	}
+
+	status := models.AttendanceGoing
+	if len(args) == 2 {
+		switch strings.ToLower(args[1]) {
+		case "maybe", "возможно":
+			status = models.AttendanceMaybe
+		default:
+			h.sendMessage(msg.Chat.ID, lc.T("join_bad_argument"))
+			return
+		}
+	}
+
+	h.sendMessage(msg.Chat.ID, escapeHTML(h.setAttendance(msg.Chat.ID, user, eventID, status)))
+}
+
+// /leave ID - отказаться от участия
+func (h *BotHandler) handleLeave(msg *tgbotapi.Message, user *models.User) {
+	eventID, ok := parseIDArgument(msg)
+	if !ok {
+		h.sendMessage(msg.Chat.ID, h.locale(user.TelegramID).T("leave_usage"))
+		return
+	}
+
+	h.sendMessage(msg.Chat.ID, escapeHTML(h.setAttendance(msg.Chat.ID, user, eventID, models.AttendanceNotGoing)))
+}
+
+// Участники с одним статусом для карточки мероприятия
+type attendeeGroup struct {
+	Label string
+	Names []string
+}
+
+// Участники мероприятия по статусам: идут, возможно, в очереди
+func (h *BotHandler) attendeeGroups(lc *i18n.Locale, event *models.Event, viewerID int64) ([]attendeeGroup, error) {
+	attendees, err := h.repo.GetAttendees(event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	viewerResponded := false
+	for _, a := range attendees {
+		if a.UserID == viewerID && a.Status != models.AttendanceNotGoing {
+			viewerResponded = true
+		}
+	}
+
+	byStatus := make(map[models.AttendanceStatus][]string)
+	for _, a := range attendees {
+		name := a.Name
+		if a.Username != "" {
+			name = "@" + a.Username
+		}
+		if viewerID != a.UserID && viewerID != event.CreatedBy && h.hidesName(a.UserID, viewerResponded) {
+			name = lc.T("attendee_hidden")
+		}
+		byStatus[a.Status] = append(byStatus[a.Status], name)
+	}
+
+	var groups []attendeeGroup
+	for _, status := range []models.AttendanceStatus{models.AttendanceGoing, models.AttendanceMaybe, models.AttendanceWaitlist} {
+		if names := byStatus[status]; len(names) > 0 {
+			groups = append(groups, attendeeGroup{Label: attendanceLabel(lc, status), Names: names})
+		}
+	}
+	return groups, nil
+}
+
+// Скрывает ли участник своё имя от того, кто смотрит список;
+// responded - смотрящий сам ответил на мероприятие
+func (h *BotHandler) hidesName(userID int64, responded bool) bool {
+	settings, err := h.userSettings(userID)
+	if err != nil {
+		log.Printf("Get user settings error: %v", err)
+		return true
+	}
+
+	switch settings.Privacy {
+	case models.PrivacyAttendees:
+		return !responded
+	case models.PrivacyOrganizer:
+		return true
+	}
+	return false
+}
+
+// Кнопки ответа на мероприятие
+func attendanceButtons(lc *i18n.Locale, eventID int64) []tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(attendanceLabel(lc, models.AttendanceGoing), encodeCallback(actionJoin, eventID)),
+		tgbotapi.NewInlineKeyboardButtonData(attendanceLabel(lc, models.AttendanceMaybe), encodeCallback(actionMaybe, eventID)),
+		tgbotapi.NewInlineKeyboardButtonData(attendanceLabel(lc, models.AttendanceNotGoing), encodeCallback(actionLeave, eventID)),
+	)
+}
@@ -0,0 +1,119 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const searchPageSize = 5
+
+// /search текст - поиск мероприятий по названию, описанию и месту
+func (h *BotHandler) handleSearch(msg *tgbotapi.Message, user *models.User) {
+	chatID := msg.Chat.ID
+	lc := h.locale(user.TelegramID)
+
+	query := strings.TrimSpace(msg.CommandArguments())
+	if query == "" {
+		h.sendMessage(chatID, lc.T("search_usage"))
+		return
+	}
+
+	text, keyboard, err := h.searchPage(chatID, user, h.queries.add(query), query, 0)
+	if err != nil {
+		log.Printf("Search events error: %v", err)
+		h.sendMessage(chatID, lc.T("search_error"))
+		return
+	}
+	if keyboard == nil {
+		h.sendMessage(chatID, text)
+		return
+	}
+	h.sendMessageWithKeyboard(chatID, text, *keyboard)
+}
+
+// Кнопка листания результатов поиска
+func (h *BotHandler) handleSearchPageButton(msg *tgbotapi.Message, user *models.User, searchID, page int64) string {
+	lc := h.locale(user.TelegramID)
+	query, ok := h.queries.get(searchID)
+	if !ok || page < 0 {
+		return lc.T("search_expired")
+	}
+
+	text, keyboard, err := h.searchPage(msg.Chat.ID, user, searchID, query, int(page))
+	if err != nil {
+		log.Printf("Search events error: %v", err)
+		return lc.T("search_error")
+	}
+	h.editMessageWithKeyboard(msg.Chat.ID, msg.MessageID, text, keyboard)
+	return ""
+}
+
+// Страница результатов поиска (с нуля) среди мероприятий чата и кнопки под ней
+func (h *BotHandler) searchPage(chatID int64, user *models.User, searchID int64, query string, page int) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	events, total, err := h.repo.SearchEvents(query, chatID, searchPageSize, page*searchPageSize)
+	if err != nil {
+		return "", nil, err
+	}
+
+	lc := h.locale(user.TelegramID)
+	if total == 0 {
+		return render(lc, "search_empty", query), nil, nil
+	}
+	pages := (total + searchPageSize - 1) / searchPageSize
+	if len(events) == 0 {
+		return render(lc, "search_page_gone", query), nil, nil
+	}
+
+	loc := h.userLocation(user.TelegramID)
+	now := time.Now()
+
+	var items []eventItem
+	var buttons []tgbotapi.InlineKeyboardButton
+	for i := range events {
+		event := &events[i]
+		num := page*searchPageSize + i + 1
+
+		// У серии показываем ближайшее повторение, если оно есть
+		shown := event
+		if event.IsRecurring() {
+			if next, err := h.repo.NextOccurrence(event, now); err == nil && next != nil {
+				shown = next
+			}
+		}
+
+		items = append(items, eventItem{Num: num, Event: event, Shown: shown, Loc: loc})
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("ℹ️ %d", num), encodeCallback(actionDetails, occurrenceArgs(shown)...)))
+	}
+
+	text := render(lc, "search_results", map[string]interface{}{
+		"Query": query,
+		"Total": total,
+		"Page":  page + 1,
+		"Pages": pages,
+		"Items": items,
+	})
+
+	rows := [][]tgbotapi.InlineKeyboardButton{buttons}
+	var nav []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(lc.T("button_prev"),
+			encodeCallback(actionSearchPage, searchID, int64(page-1))))
+	}
+	if page+1 < pages {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(lc.T("button_next"),
+			encodeCallback(actionSearchPage, searchID, int64(page+1))))
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return text, &keyboard, nil
+}
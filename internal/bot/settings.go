@@ -0,0 +1,224 @@
+package bot
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Пункты меню /settings; номер пункта передаётся в кнопке
+const (
+	settingTimezone = iota
+	settingLanguage
+	settingReminders
+	settingDigest
+	settingPrivacy
+)
+
+// Варианты сроков напоминаний, по кругу при нажатии на кнопку.
+// Пустая строка - сроки по умолчанию из REMINDER_OFFSETS
+var reminderPresets = []string{"", "24h,1h", "24h", "1h", "15m", models.RemindersOff}
+
+var digestOptions = []models.DigestFrequency{models.DigestOff, models.DigestDaily, models.DigestWeekly}
+
+var privacyOptions = []models.Privacy{models.PrivacyEveryone, models.PrivacyAttendees, models.PrivacyOrganizer}
+
+// Настройки пользователя из кэша, а если их там нет - из базы
+func (h *BotHandler) userSettings(userID int64) (*models.UserSettings, error) {
+	if settings, ok := h.settings.get(userID); ok {
+		return &settings, nil
+	}
+
+	settings, err := h.repo.GetUserSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+	h.settings.add(*settings)
+	return settings, nil
+}
+
+// Изменение настроек пользователя в базе и в кэше; change возвращает
+// false, если менять нечего
+func (h *BotHandler) updateUserSettings(userID int64, change func(*models.UserSettings) bool) error {
+	return h.settings.update(userID, h.repo.GetUserSettings, change, h.repo.SaveUserSettings)
+}
+
+// Сроки напоминаний пользователя; если он их не менял - сроки по умолчанию
+func (h *BotHandler) userReminderOffsets(userID int64) []time.Duration {
+	settings, err := h.userSettings(userID)
+	if err != nil {
+		log.Printf("Get user settings error: %v", err)
+		return h.reminderOffsets
+	}
+	if offsets, ok := settings.ReminderOffsets(); ok {
+		return offsets
+	}
+	return h.reminderOffsets
+}
+
+// Самый ранний срок напоминания среди сроков по умолчанию и вариантов меню
+func (h *BotHandler) maxReminderOffset() time.Duration {
+	var max time.Duration
+	for _, o := range h.reminderOffsets {
+		if o > max {
+			max = o
+		}
+	}
+	for _, preset := range reminderPresets {
+		offsets, _ := (&models.UserSettings{Reminders: preset}).ReminderOffsets()
+		for _, o := range offsets {
+			if o > max {
+				max = o
+			}
+		}
+	}
+	return max
+}
+
+// /settings - меню настроек
+func (h *BotHandler) handleSettings(msg *tgbotapi.Message, user *models.User) {
+	lc := h.locale(user.TelegramID)
+	text, keyboard, err := h.settingsMenu(lc, user)
+	if err != nil {
+		log.Printf("Get user settings error: %v", err)
+		h.sendMessage(msg.Chat.ID, lc.T("settings_error"))
+		return
+	}
+	h.sendMessageWithKeyboard(msg.Chat.ID, text, keyboard)
+}
+
+// Текст и кнопки меню настроек
+func (h *BotHandler) settingsMenu(lc *i18n.Locale, user *models.User) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	settings, err := h.userSettings(user.TelegramID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := render(lc, "settings", map[string]string{
+		"Timezone":  h.userLocation(user.TelegramID).String(),
+		"Language":  lc.Name,
+		"Reminders": h.remindersLabel(lc, settings),
+		"Digest":    lc.T("digest_" + digestKey(settings.Digest)),
+		"Privacy":   lc.T("privacy_" + privacyKey(settings.Privacy)),
+	})
+
+	button := func(label string, setting int64) []tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, encodeCallback(actionSettings, setting)))
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		button(lc.T("button_settings_timezone"), settingTimezone),
+		button(lc.T("button_settings_language"), settingLanguage),
+		button(lc.T("button_settings_reminders"), settingReminders),
+		button(lc.T("button_settings_digest"), settingDigest),
+		button(lc.T("button_settings_privacy"), settingPrivacy),
+	)
+	return text, keyboard, nil
+}
+
+// Сроки напоминаний словами: «за 1 день, 1 час (по умолчанию)»
+func (h *BotHandler) remindersLabel(lc *i18n.Locale, settings *models.UserSettings) string {
+	offsets, custom := settings.ReminderOffsets()
+	if !custom {
+		offsets = h.reminderOffsets
+	}
+	if len(offsets) == 0 {
+		return lc.T("reminders_off")
+	}
+
+	offsets = append([]time.Duration(nil), offsets...)
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] > offsets[j] })
+
+	parts := make([]string, len(offsets))
+	for i, o := range offsets {
+		parts[i] = formatOffset(lc, o)
+	}
+	label := lc.T("reminders_before", strings.Join(parts, ", "))
+	if !custom {
+		label += lc.T("settings_default")
+	}
+	return label
+}
+
+// Ключи подписей в каталоге для значений по умолчанию
+func digestKey(digest models.DigestFrequency) string {
+	if digest == models.DigestOff {
+		return "off"
+	}
+	return string(digest)
+}
+
+func privacyKey(privacy models.Privacy) string {
+	if privacy == models.PrivacyEveryone {
+		return "everyone"
+	}
+	return string(privacy)
+}
+
+// Кнопка меню настроек. Пояс и язык выбираются отдельным сообщением,
+// остальные настройки переключаются по кругу прямо в меню
+func (h *BotHandler) handleSettingsButton(msg *tgbotapi.Message, user *models.User, setting int64) string {
+	lc := h.locale(user.TelegramID)
+
+	switch setting {
+	case settingTimezone:
+		h.sendTimezoneMenu(msg.Chat.ID, user)
+		return ""
+	case settingLanguage:
+		h.sendLanguageMenu(msg.Chat.ID, user)
+		return ""
+	}
+
+	var change func(settings *models.UserSettings)
+	switch setting {
+	case settingReminders:
+		change = func(settings *models.UserSettings) {
+			settings.Reminders = nextOption(reminderPresets, settings.Reminders)
+		}
+	case settingDigest:
+		change = func(settings *models.UserSettings) {
+			settings.Digest = nextOption(digestOptions, settings.Digest)
+		}
+	case settingPrivacy:
+		change = func(settings *models.UserSettings) {
+			settings.Privacy = nextOption(privacyOptions, settings.Privacy)
+		}
+	default:
+		return lc.T("unknown_action")
+	}
+
+	// Следующий вариант выбирается от сохранённого под блокировкой, чтобы
+	// два быстрых нажатия не сохранили один и тот же вариант
+	err := h.updateUserSettings(user.TelegramID, func(settings *models.UserSettings) bool {
+		change(settings)
+		return true
+	})
+	if err != nil {
+		log.Printf("Save user settings error: %v", err)
+		return lc.T("settings_save_error")
+	}
+
+	text, keyboard, err := h.settingsMenu(lc, user)
+	if err != nil {
+		log.Printf("Get user settings error: %v", err)
+		return lc.T("settings_error")
+	}
+	h.editMessageWithKeyboard(msg.Chat.ID, msg.MessageID, text, &keyboard)
+	return lc.T("settings_saved")
+}
+
+// Следующий вариант по кругу; неизвестное значение сменяется первым вариантом
+func nextOption[T comparable](options []T, current T) T {
+	for i, option := range options {
+		if option == current {
+			return options[(i+1)%len(options)]
+		}
+	}
+	return options[0]
+}
@@ -0,0 +1,93 @@
+package bot
+
+import (
+	"sync"
+
+	"event-planner-bot/internal/models"
+)
+
+// Сколько пользователей держать в кэше настроек; при переполнении кэш очищается
+const maxCachedSettings = 10000
+
+// Настройки пользователей в памяти: язык и часовой пояс нужны почти
+// в каждом ответе, поэтому за ними не ходим в базу каждый раз.
+// Обновления обрабатываются параллельно, поэтому прочитанные из базы
+// настройки не затирают уже сохранённые (add), а изменения идут
+// по одному (update)
+type settingsCache struct {
+	mu       sync.RWMutex
+	saveMu   sync.Mutex
+	settings map[int64]models.UserSettings
+}
+
+func newSettingsCache() *settingsCache {
+	return &settingsCache{settings: make(map[int64]models.UserSettings)}
+}
+
+func (c *settingsCache) get(userID int64) (models.UserSettings, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	settings, ok := c.settings[userID]
+	return settings, ok
+}
+
+// Настройки, прочитанные из базы. Если пока их читали, настройки
+// успели сохранить, в кэше остаются сохранённые
+func (c *settingsCache) add(settings models.UserSettings) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.settings[settings.UserID]; ok {
+		return
+	}
+	c.put(settings)
+}
+
+// Запись в кэш; вызывается под c.mu
+func (c *settingsCache) put(settings models.UserSettings) {
+	if len(c.settings) >= maxCachedSettings {
+		c.settings = make(map[int64]models.UserSettings)
+	}
+	c.settings[settings.UserID] = settings
+}
+
+// Изменение настроек: чтение (из кэша или через load), change и сохранение
+// через store идут под одной блокировкой, чтобы два одновременных
+// изменения разных настроек не затирали друг друга. change возвращает
+// false, если сохранять нечего. Если сохранить не удалось, запись из кэша
+// удаляется
+func (c *settingsCache) update(userID int64, load func(int64) (*models.UserSettings, error),
+	change func(*models.UserSettings) bool, store func(*models.UserSettings) error) error {
+	c.saveMu.Lock()
+	defer c.saveMu.Unlock()
+
+	settings, ok := c.get(userID)
+	if !ok {
+		loaded, err := load(userID)
+		if err != nil {
+			return err
+		}
+		settings = *loaded
+	}
+	if !change(&settings) {
+		return nil
+	}
+
+	if err := store(&settings); err != nil {
+		c.forget(userID)
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.put(settings)
+	return nil
+}
+
+func (c *settingsCache) forget(userID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.settings, userID)
+}
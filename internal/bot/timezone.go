@@ -0,0 +1,137 @@
+package bot
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"event-planner-bot/internal/i18n"
+	"event-planner-bot/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Часовые поясы для быстрого выбора кнопками; label - ключ подписи в каталоге
+var popularTimezones = []struct {
+	name  string
+	label string
+}{
+	{"Europe/Kaliningrad", "tz_kaliningrad"},
+	{"Europe/Moscow", "tz_moscow"},
+	{"Europe/Samara", "tz_samara"},
+	{"Asia/Yekaterinburg", "tz_yekaterinburg"},
+	{"Asia/Omsk", "tz_omsk"},
+	{"Asia/Novosibirsk", "tz_novosibirsk"},
+	{"Asia/Irkutsk", "tz_irkutsk"},
+	{"Asia/Vladivostok", "tz_vladivostok"},
+	{"UTC", "tz_utc"},
+}
+
+// Часовой пояс пользователя, а если он не выбран - пояс по умолчанию
+func (h *BotHandler) userLocation(userID int64) *time.Location {
+	settings, err := h.userSettings(userID)
+	if err != nil {
+		log.Printf("Get user settings error: %v", err)
+		return h.defaultLocation
+	}
+	if settings.Timezone == "" {
+		return h.defaultLocation
+	}
+
+	loc, err := models.ParseTimezone(settings.Timezone)
+	if err != nil {
+		log.Printf("Bad timezone %q for user %d: %v", settings.Timezone, userID, err)
+		return h.defaultLocation
+	}
+	return loc
+}
+
+// /timezone [пояс] - показать или изменить часовой пояс
+func (h *BotHandler) handleTimezone(msg *tgbotapi.Message, user *models.User) {
+	if arg := strings.TrimSpace(msg.CommandArguments()); arg != "" {
+		h.sendMessage(msg.Chat.ID, escapeHTML(h.setTimezone(user, arg)))
+		return
+	}
+	h.sendTimezoneMenu(msg.Chat.ID, user)
+}
+
+// Текущий часовой пояс и кнопки выбора
+func (h *BotHandler) sendTimezoneMenu(chatID int64, user *models.User) {
+	loc := h.userLocation(user.TelegramID)
+	lc := h.locale(user.TelegramID)
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i := 0; i < len(popularTimezones); i += 2 {
+		var row []tgbotapi.InlineKeyboardButton
+		for j := i; j < i+2 && j < len(popularTimezones); j++ {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(lc.T(popularTimezones[j].label),
+				encodeCallback(actionTimezone, int64(j))))
+		}
+		rows = append(rows, row)
+	}
+
+	h.sendMessageWithKeyboard(chatID, render(lc, "timezone", map[string]string{
+		"Name": loc.String(),
+		"Now":  lc.Clock(time.Now().In(loc)),
+	}), tgbotapi.NewInlineKeyboardMarkup(rows...))
+}
+
+// Кнопка выбора часового пояса
+func (h *BotHandler) handleTimezoneButton(user *models.User, idx int64) string {
+	if idx < 0 || idx >= int64(len(popularTimezones)) {
+		return h.locale(user.TelegramID).T("timezone_unknown_button")
+	}
+	return h.setTimezone(user, popularTimezones[idx].name)
+}
+
+// Сохранение часового пояса; возвращает текст для пользователя
+func (h *BotHandler) setTimezone(user *models.User, name string) string {
+	lc := h.locale(user.TelegramID)
+	loc, err := models.ParseTimezone(name)
+	if err != nil {
+		return lc.T("timezone_unknown")
+	}
+
+	err = h.updateUserSettings(user.TelegramID, func(settings *models.UserSettings) bool {
+		settings.Timezone = loc.String()
+		return true
+	})
+	if err != nil {
+		log.Printf("Save user settings error: %v", err)
+		return lc.T("settings_save_error")
+	}
+
+	return lc.T("timezone_set", loc.String(), lc.Clock(time.Now().In(loc)))
+}
+
+// Время для показа пользователю в его часовом поясе
+func formatEventTime(lc *i18n.Locale, t time.Time, loc *time.Location) string {
+	return lc.DateTime(t.In(loc))
+}
+
+// Период мероприятия: «12.05.2026 19:00–21:00», «12.05.2026, весь день»
+func formatEventPeriod(lc *i18n.Locale, event *models.Event, loc *time.Location) string {
+	// Мероприятие на весь день привязано к датам в поясе создателя
+	if event.AllDay {
+		loc = event.TimeLocation(loc)
+	}
+
+	start, end := event.EventDate.In(loc), event.EndTime().In(loc)
+
+	if event.AllDay {
+		last := end.Add(-time.Nanosecond)
+		if sameDay(start, last) {
+			return lc.T("all_day_date", lc.Date(start))
+		}
+		return lc.T("all_day_dates", lc.Date(start), lc.Date(last))
+	}
+
+	if sameDay(start, end) {
+		return lc.DateTime(start) + "–" + lc.Clock(end)
+	}
+	return lc.DateTime(start) + " – " + lc.DateTime(end)
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
@@ -0,0 +1,293 @@
+package database
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+
+	"event-planner-bot/internal/models"
+)
+
+// Изменение ответа пользователя на мероприятие.
+// Если мест нет, пользователь попадает в очередь; если участник отказался,
+// первый из очереди получает его место. Всё в одной транзакции, чтобы
+// параллельные запросы не записали больше людей, чем есть мест
+func (s *Storage) ChangeAttendance(eventID, userID int64, status models.AttendanceStatus) (*models.AttendanceChange, error) {
+	log.Printf("Пользователь %d: мероприятие %d, статус %s", userID, eventID, status)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var capacity int
+	if err := tx.QueryRow(`SELECT capacity FROM events WHERE id = ?`, eventID).Scan(&capacity); err != nil {
+		return nil, err
+	}
+
+	var current models.AttendanceStatus
+	err = tx.QueryRow(`SELECT status FROM attendees WHERE event_id = ? AND user_id = ?`,
+		eventID, userID).Scan(&current)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	change := &models.AttendanceChange{Status: status}
+
+	if status == models.AttendanceGoing {
+		// Повторное нажатие не должно сбрасывать место в очереди
+		if current == models.AttendanceGoing || current == models.AttendanceWaitlist {
+			change.Status = current
+			return change, nil
+		}
+
+		if capacity > 0 {
+			going, err := countGoing(tx, eventID)
+			if err != nil {
+				return nil, err
+			}
+			if going >= capacity {
+				change.Status = models.AttendanceWaitlist
+			}
+		}
+	}
+
+	query := `
+    INSERT INTO attendees (event_id, user_id, status, updated_at)
+    VALUES (?, ?, ?, ?)
+    ON CONFLICT (event_id, user_id) DO UPDATE SET
+        status = excluded.status,
+        updated_at = excluded.updated_at`
+
+	if _, err := tx.Exec(query, eventID, userID, change.Status, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	if current == models.AttendanceGoing && change.Status != models.AttendanceGoing {
+		change.Promoted, err = promoteWaitlist(tx, eventID, capacity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// Перевод людей из очереди на свободные места (например, после увеличения
+// количества мест). Возвращает Telegram ID переведённых
+func (s *Storage) PromoteWaitlist(eventID int64) ([]int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var capacity int
+	if err := tx.QueryRow(`SELECT capacity FROM events WHERE id = ?`, eventID).Scan(&capacity); err != nil {
+		return nil, err
+	}
+
+	promoted, err := promoteWaitlist(tx, eventID, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	return promoted, tx.Commit()
+}
+
+func countGoing(tx *sql.Tx, eventID int64) (int, error) {
+	var going int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM attendees WHERE event_id = ? AND status = ?`,
+		eventID, models.AttendanceGoing).Scan(&going)
+	return going, err
+}
+
+// Заполнение свободных мест из очереди в порядке записи
+func promoteWaitlist(tx *sql.Tx, eventID int64, capacity int) ([]int64, error) {
+	limit := -1 // без ограничения мест переводим всю очередь
+	if capacity > 0 {
+		going, err := countGoing(tx, eventID)
+		if err != nil {
+			return nil, err
+		}
+		limit = capacity - going
+		if limit <= 0 {
+			return nil, nil
+		}
+	}
+
+	rows, err := tx.Query(`
+    SELECT user_id
+    FROM attendees
+    WHERE event_id = ? AND status = ?
+    ORDER BY updated_at, rowid
+    LIMIT ?`, eventID, models.AttendanceWaitlist, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var promoted []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		promoted = append(promoted, userID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	for _, userID := range promoted {
+		_, err := tx.Exec(`UPDATE attendees SET status = ?, updated_at = ? WHERE event_id = ? AND user_id = ?`,
+			models.AttendanceGoing, now, eventID, userID)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Пользователь %d переведён из очереди на мероприятие %d", userID, eventID)
+	}
+
+	return promoted, nil
+}
+
+// Ответ пользователя на мероприятие; пустая строка, если ответа нет
+func (s *Storage) GetAttendance(eventID, userID int64) (models.AttendanceStatus, error) {
+	var status models.AttendanceStatus
+	err := s.db.QueryRow(`SELECT status FROM attendees WHERE event_id = ? AND user_id = ?`,
+		eventID, userID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return status, err
+}
+
+// Список ответивших на мероприятие
+func (s *Storage) GetAttendees(eventID int64) ([]models.Attendee, error) {
+	log.Printf("Получение участников мероприятия ID: %d", eventID)
+
+	query := `
+    SELECT a.event_id, a.user_id, COALESCE(u.username, ''), COALESCE(u.first_name, ''),
+           a.status, a.created_at, a.updated_at
+    FROM attendees a
+    LEFT JOIN users u ON u.telegram_id = a.user_id
+    WHERE a.event_id = ?
+    ORDER BY a.updated_at, a.rowid`
+
+	rows, err := s.db.Query(query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attendees []models.Attendee
+
+	for rows.Next() {
+		var a models.Attendee
+		err := rows.Scan(
+			&a.EventID,
+			&a.UserID,
+			&a.Username,
+			&a.Name,
+			&a.Status,
+			&a.CreatedAt,
+			&a.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		attendees = append(attendees, a)
+	}
+
+	return attendees, rows.Err()
+}
+
+// Кому сообщать об изменениях мероприятия: всем, кто не отказался
+func (s *Storage) GetEventSubscribers(eventID int64) ([]int64, error) {
+	rows, err := s.db.Query(`
+    SELECT user_id
+    FROM attendees
+    WHERE event_id = ? AND status != ?
+    ORDER BY user_id`, eventID, models.AttendanceNotGoing)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscribers []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		subscribers = append(subscribers, userID)
+	}
+	return subscribers, rows.Err()
+}
+
+// Количество ответов на мероприятие по статусам
+func (s *Storage) CountAttendees(eventID int64) (map[models.AttendanceStatus]int, error) {
+	rows, err := s.db.Query(`
+    SELECT status, COUNT(*)
+    FROM attendees
+    WHERE event_id = ?
+    GROUP BY status`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[models.AttendanceStatus]int)
+	for rows.Next() {
+		var status models.AttendanceStatus
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[status] = count
+	}
+	return counts, rows.Err()
+}
+
+// Количество идущих на каждое из мероприятий (для списков)
+func (s *Storage) CountGoingByEvents(eventIDs []int64) (map[int64]int, error) {
+	counts := make(map[int64]int)
+	if len(eventIDs) == 0 {
+		return counts, nil
+	}
+
+	args := make([]interface{}, 0, len(eventIDs)+1)
+	args = append(args, models.AttendanceGoing)
+	for _, id := range eventIDs {
+		args = append(args, id)
+	}
+
+	query := `
+    SELECT event_id, COUNT(*)
+    FROM attendees
+    WHERE status = ? AND event_id IN (?` + strings.Repeat(", ?", len(eventIDs)-1) + `)
+    GROUP BY event_id`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var eventID int64
+		var count int
+		if err := rows.Scan(&eventID, &count); err != nil {
+			return nil, err
+		}
+		counts[eventID] = count
+	}
+	return counts, rows.Err()
+}
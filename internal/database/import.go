@@ -0,0 +1,45 @@
+package database
+
+import (
+	"log"
+	"time"
+
+	"event-planner-bot/internal/models"
+)
+
+// Есть ли уже в чате chatID мероприятие с таким названием и началом
+func (s *Storage) EventExists(title string, start time.Time, chatID int64) (bool, error) {
+	var exists bool
+	err := s.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM events WHERE title = ? AND date = ? AND chat_id = ?)`,
+		title, start.UTC(), chatID).Scan(&exists)
+	return exists, err
+}
+
+// Создание мероприятий из загруженного файла одной транзакцией:
+// либо появляются все, либо ни одного. ID мероприятий заполняются
+func (s *Storage) ImportEvents(events []models.ImportedEvent) error {
+	log.Printf("Импорт мероприятий: %d", len(events))
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i := range events {
+		event := &events[i].Event
+		if err := insertEvent(tx, event); err != nil {
+			return err
+		}
+
+		for _, occurrence := range events[i].Exceptions {
+			_, err := tx.Exec(`INSERT OR IGNORE INTO event_exceptions (event_id, occurrence_date) VALUES (?, ?)`,
+				event.ID, occurrence.UTC())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
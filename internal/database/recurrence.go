@@ -0,0 +1,355 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"event-planner-bot/internal/models"
+	"event-planner-bot/internal/recurrence"
+)
+
+// Правило серии нельзя менять, пока от неё отделены повторения:
+// новые повторения не сопоставить с отделёнными, и они показывались бы дважды
+var ErrDetachedOccurrences = errors.New("у серии есть отделённые повторения")
+
+// Общий интерфейс *sql.DB и *sql.Tx для запросов без результата
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// Замена повторяющихся мероприятий их повторениями в промежутке [from, to).
+// keep дополнительно отбирает повторения по началу и окончанию;
+// пропущенные повторения не попадают в результат
+func (s *Storage) expandRecurring(events []models.Event, keep func(start, end time.Time) bool, from, to time.Time) ([]models.Event, error) {
+	var result []models.Event
+	expanded := false
+
+	for i := range events {
+		series := &events[i]
+		if !series.IsRecurring() {
+			result = append(result, *series)
+			continue
+		}
+
+		rule, err := recurrence.Parse(series.Recurrence)
+		if err != nil {
+			log.Printf("Неверное правило повторения у мероприятия %d: %v", series.ID, err)
+			continue
+		}
+
+		skipped, err := s.eventExceptions(series.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		duration := series.EndTime().Sub(series.EventDate)
+		start := series.EventDate.In(series.TimeLocation(time.UTC))
+		for _, t := range rule.Between(start, from.Add(-duration), to.Add(time.Second)) {
+			if skipped[t.Unix()] {
+				continue
+			}
+			occurrence := occurrenceOf(series, t)
+			if keep(occurrence.EventDate, occurrence.EndTime()) {
+				result = append(result, occurrence)
+			}
+		}
+		expanded = true
+	}
+
+	if expanded {
+		sort.SliceStable(result, func(i, j int) bool {
+			if !result[i].EventDate.Equal(result[j].EventDate) {
+				return result[i].EventDate.Before(result[j].EventDate)
+			}
+			return result[i].ID < result[j].ID
+		})
+	}
+	return result, nil
+}
+
+// Повторение серии, которое начинается в start: копия мероприятия с теми же
+// ID и длительностью. Мероприятие на весь день занимает те же календарные дни
+func occurrenceOf(series *models.Event, start time.Time) models.Event {
+	duration := series.EndTime().Sub(series.EventDate)
+
+	occurrence := *series
+	occurrence.EventDate = start.UTC()
+	occurrence.EndDate = start.Add(duration).UTC()
+	if series.AllDay {
+		days := int((duration + 12*time.Hour) / (24 * time.Hour))
+		occurrence.EndDate = start.AddDate(0, 0, days).UTC()
+	}
+	return occurrence
+}
+
+// Ближайшее повторение серии, которое ещё не закончилось к моменту after.
+// Для обычного мероприятия - оно само, если не закончилось. nil - повторений больше нет
+func (s *Storage) NextOccurrence(event *models.Event, after time.Time) (*models.Event, error) {
+	if !event.IsRecurring() {
+		if event.EndTime().After(after) {
+			return event, nil
+		}
+		return nil, nil
+	}
+
+	rule, err := recurrence.Parse(event.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	skipped, err := s.eventExceptions(event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var next *models.Event
+	rule.Each(event.EventDate.In(event.TimeLocation(time.UTC)), func(t time.Time) bool {
+		occurrence := occurrenceOf(event, t)
+		if skipped[t.Unix()] || !occurrence.EndTime().After(after) {
+			return true
+		}
+		next = &occurrence
+		return false
+	})
+	return next, nil
+}
+
+// Повторение серии, которое начинается ровно в start; nil - такого нет или оно пропущено
+func (s *Storage) GetOccurrence(event *models.Event, start time.Time) (*models.Event, error) {
+	if !event.IsRecurring() {
+		return nil, nil
+	}
+
+	rule, err := recurrence.Parse(event.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	skipped, err := s.eventExceptions(event.ID)
+	if err != nil {
+		return nil, err
+	}
+	if skipped[start.Unix()] {
+		return nil, nil
+	}
+
+	loc := event.TimeLocation(time.UTC)
+	for _, t := range rule.Between(event.EventDate.In(loc), start, start.Add(time.Second)) {
+		if t.Equal(start) {
+			occurrence := occurrenceOf(event, t)
+			return &occurrence, nil
+		}
+	}
+	return nil, nil
+}
+
+// Пропущенные повторения серии: начала в Unix-времени
+func (s *Storage) eventExceptions(eventID int64) (map[int64]bool, error) {
+	dates, err := s.GetEventExceptions(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	skipped := make(map[int64]bool, len(dates))
+	for _, date := range dates {
+		skipped[date.Unix()] = true
+	}
+	return skipped, nil
+}
+
+// Начала пропущенных повторений серии по порядку
+func (s *Storage) GetEventExceptions(eventID int64) ([]time.Time, error) {
+	rows, err := s.db.Query(`SELECT occurrence_date FROM event_exceptions WHERE event_id = ? ORDER BY occurrence_date`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dates []time.Time
+	for rows.Next() {
+		var date time.Time
+		if err := rows.Scan(&date); err != nil {
+			return nil, err
+		}
+		dates = append(dates, date.UTC())
+	}
+	return dates, rows.Err()
+}
+
+// Пропуск одного повторения серии
+func (s *Storage) SkipOccurrence(eventID int64, occurrence time.Time) error {
+	log.Printf("Пропуск повторения %s мероприятия %d", occurrence.UTC(), eventID)
+
+	_, err := s.db.Exec(`INSERT OR IGNORE INTO event_exceptions (event_id, occurrence_date) VALUES (?, ?)`,
+		eventID, occurrence.UTC())
+	return err
+}
+
+// Отделение одного повторения от серии: повторение пропускается в серии,
+// а вместо него создаётся отдельное мероприятие updated с теми же участниками
+func (s *Storage) DetachOccurrence(seriesID int64, occurrence time.Time, updated *models.Event) error {
+	log.Printf("Отделение повторения %s мероприятия %d", occurrence.UTC(), seriesID)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	updated.Recurrence = ""
+	if err := insertEvent(tx, updated); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`INSERT OR IGNORE INTO event_exceptions (event_id, occurrence_date, detached_id) VALUES (?, ?, ?)`,
+		seriesID, occurrence.UTC(), updated.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+    INSERT INTO attendees (event_id, user_id, status, created_at, updated_at)
+    SELECT ?, user_id, status, created_at, updated_at
+    FROM attendees
+    WHERE event_id = ?`, updated.ID, seriesID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Исключение серии: пропущенное повторение или отделённое в мероприятие detachedID
+type eventException struct {
+	date       time.Time
+	detachedID int64
+}
+
+// Перенос исключений серии old при её изменении на updated. Исключения
+// хранятся по началу повторения, поэтому при том же правиле k-е повторение
+// старой серии становится k-м повторением новой. Если правило изменилось,
+// повторения не сопоставить: пропуски, которые не попадают на новое
+// правило, удаляются, а при отделённых повторениях возвращается
+// ErrDetachedOccurrences
+func moveExceptions(tx *sql.Tx, old, updated *models.Event) error {
+	if !old.IsRecurring() {
+		return nil
+	}
+	sameRule := old.Recurrence == updated.Recurrence && old.Timezone == updated.Timezone
+	if sameRule && old.EventDate.Equal(updated.EventDate) {
+		return nil
+	}
+
+	rows, err := tx.Query(`SELECT occurrence_date, detached_id FROM event_exceptions WHERE event_id = ? ORDER BY occurrence_date`, old.ID)
+	if err != nil {
+		return err
+	}
+	var exceptions []eventException
+	for rows.Next() {
+		var e eventException
+		if err := rows.Scan(&e.date, &e.detachedID); err != nil {
+			rows.Close()
+			return err
+		}
+		exceptions = append(exceptions, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(exceptions) == 0 {
+		return nil
+	}
+
+	var moved []eventException
+	if sameRule {
+		moved, err = shiftExceptions(exceptions, old, updated)
+	} else {
+		moved, err = keepMatchingExceptions(exceptions, updated)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM event_exceptions WHERE event_id = ?`, old.ID); err != nil {
+		return err
+	}
+	for _, e := range moved {
+		_, err := tx.Exec(`INSERT OR IGNORE INTO event_exceptions (event_id, occurrence_date, detached_id) VALUES (?, ?, ?)`,
+			old.ID, e.date.UTC(), e.detachedID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Исключения сдвинутой серии с тем же правилом: по номеру повторения.
+// Исключения, которых нет среди повторений, выбрасываются
+func shiftExceptions(exceptions []eventException, old, updated *models.Event) ([]eventException, error) {
+	rule, err := recurrence.Parse(old.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	last := exceptions[len(exceptions)-1].date
+	index := make(map[int64]int)
+	n := 0
+	rule.Each(old.EventDate.In(old.TimeLocation(time.UTC)), func(t time.Time) bool {
+		if t.After(last) {
+			return false
+		}
+		index[t.Unix()] = n
+		n++
+		return true
+	})
+
+	var starts []time.Time
+	rule.Each(updated.EventDate.In(updated.TimeLocation(time.UTC)), func(t time.Time) bool {
+		if len(starts) == n {
+			return false
+		}
+		starts = append(starts, t)
+		return true
+	})
+
+	var moved []eventException
+	for _, e := range exceptions {
+		i, ok := index[e.date.Unix()]
+		if !ok || i >= len(starts) {
+			continue
+		}
+		moved = append(moved, eventException{date: starts[i], detachedID: e.detachedID})
+	}
+	return moved, nil
+}
+
+// Исключения серии с новым правилом: остаются пропуски, которые попадают
+// на повторения нового правила
+func keepMatchingExceptions(exceptions []eventException, updated *models.Event) ([]eventException, error) {
+	for _, e := range exceptions {
+		if e.detachedID != 0 {
+			return nil, ErrDetachedOccurrences
+		}
+	}
+	if !updated.IsRecurring() {
+		return nil, nil
+	}
+
+	rule, err := recurrence.Parse(updated.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	start := updated.EventDate.In(updated.TimeLocation(time.UTC))
+	var moved []eventException
+	for _, e := range exceptions {
+		if len(rule.Between(start, e.date, e.date.Add(time.Second))) > 0 {
+			moved = append(moved, e)
+		}
+	}
+	return moved, nil
+}
@@ -0,0 +1,169 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"event-planner-bot/internal/models"
+)
+
+func TestShiftExceptions(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(loc *time.Location, month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, loc)
+	}
+	series := func(start time.Time, rule string) *models.Event {
+		return &models.Event{ID: 1, EventDate: start.UTC(), Recurrence: rule, Timezone: start.Location().String()}
+	}
+
+	tests := []struct {
+		name       string
+		old        *models.Event
+		updated    *models.Event
+		exceptions []eventException
+		want       []eventException
+	}{
+		{
+			name:    "daily series moved by a day and two hours",
+			old:     series(at(time.UTC, 10, 1, 10), "FREQ=DAILY"),
+			updated: series(at(time.UTC, 10, 2, 12), "FREQ=DAILY"),
+			exceptions: []eventException{
+				{date: at(time.UTC, 10, 3, 10)},
+				{date: at(time.UTC, 10, 5, 10), detachedID: 42},
+			},
+			want: []eventException{
+				{date: at(time.UTC, 10, 4, 12)},
+				{date: at(time.UTC, 10, 6, 12), detachedID: 42},
+			},
+		},
+		{
+			name:    "weekly BYDAY across DST keeps the local time",
+			old:     series(at(berlin, 10, 19, 19), "FREQ=WEEKLY;BYDAY=MO,TH"),
+			updated: series(at(berlin, 10, 22, 20), "FREQ=WEEKLY;BYDAY=MO,TH"),
+			exceptions: []eventException{
+				{date: at(berlin, 10, 22, 19)},
+				{date: at(berlin, 10, 26, 19), detachedID: 7},
+			},
+			want: []eventException{
+				{date: at(berlin, 10, 26, 20)},
+				{date: at(berlin, 10, 29, 20), detachedID: 7},
+			},
+		},
+		{
+			name:    "exception outside the series is dropped",
+			old:     series(at(time.UTC, 10, 1, 10), "FREQ=DAILY"),
+			updated: series(at(time.UTC, 10, 1, 11), "FREQ=DAILY"),
+			exceptions: []eventException{
+				{date: at(time.UTC, 10, 2, 9)},
+				{date: at(time.UTC, 10, 3, 10)},
+			},
+			want: []eventException{
+				{date: at(time.UTC, 10, 3, 11)},
+			},
+		},
+		{
+			name:    "COUNT limits the moved exceptions",
+			old:     series(at(time.UTC, 10, 1, 10), "FREQ=DAILY;COUNT=3"),
+			updated: series(at(time.UTC, 10, 2, 10), "FREQ=DAILY;COUNT=3"),
+			exceptions: []eventException{
+				{date: at(time.UTC, 10, 3, 10)},
+				{date: at(time.UTC, 10, 4, 10)},
+			},
+			want: []eventException{
+				{date: at(time.UTC, 10, 4, 10)},
+			},
+		},
+		{
+			name:    "UNTIL cuts off the shifted series",
+			old:     series(at(time.UTC, 10, 1, 10), "FREQ=DAILY;UNTIL=20261005"),
+			updated: series(at(time.UTC, 10, 4, 10), "FREQ=DAILY;UNTIL=20261005"),
+			exceptions: []eventException{
+				{date: at(time.UTC, 10, 1, 10)},
+				{date: at(time.UTC, 10, 3, 10), detachedID: 5},
+			},
+			want: []eventException{
+				{date: at(time.UTC, 10, 4, 10)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := shiftExceptions(tt.exceptions, tt.old, tt.updated)
+			if err != nil {
+				t.Fatalf("shiftExceptions error: %v", err)
+			}
+			assertExceptions(t, got, tt.want)
+		})
+	}
+}
+
+func TestKeepMatchingExceptions(t *testing.T) {
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	// Четверг, 1 октября 2026, 10:00
+	event := func(rule string) *models.Event {
+		return &models.Event{ID: 1, EventDate: at(10, 1, 10), Recurrence: rule, Timezone: "UTC"}
+	}
+
+	tests := []struct {
+		name       string
+		updated    *models.Event
+		exceptions []eventException
+		want       []eventException
+		wantErr    error
+	}{
+		{
+			name:       "skips on the new rule are kept",
+			updated:    event("FREQ=WEEKLY;BYDAY=TH"),
+			exceptions: []eventException{{date: at(10, 8, 10)}, {date: at(10, 9, 10)}, {date: at(10, 15, 10)}},
+			want:       []eventException{{date: at(10, 8, 10)}, {date: at(10, 15, 10)}},
+		},
+		{
+			name:       "skips past COUNT are dropped",
+			updated:    event("FREQ=DAILY;COUNT=3"),
+			exceptions: []eventException{{date: at(10, 2, 10)}, {date: at(10, 5, 10)}},
+			want:       []eventException{{date: at(10, 2, 10)}},
+		},
+		{
+			name:       "series becomes a single event",
+			updated:    event(""),
+			exceptions: []eventException{{date: at(10, 2, 10)}},
+			want:       nil,
+		},
+		{
+			name:       "detached occurrence",
+			updated:    event("FREQ=WEEKLY;BYDAY=TH"),
+			exceptions: []eventException{{date: at(10, 8, 10)}, {date: at(10, 2, 10), detachedID: 3}},
+			wantErr:    ErrDetachedOccurrences,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := keepMatchingExceptions(tt.exceptions, tt.updated)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("keepMatchingExceptions error = %v, want %v", err, tt.wantErr)
+			}
+			assertExceptions(t, got, tt.want)
+		})
+	}
+}
+
+func assertExceptions(t *testing.T, got, want []eventException) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("exceptions = %v, want %v", got, want)
+	}
+	for i := range got {
+		if !got[i].date.Equal(want[i].date) || got[i].detachedID != want[i].detachedID {
+			t.Errorf("exception %d = %v (detached %d), want %v (detached %d)",
+				i, got[i].date, got[i].detachedID, want[i].date, want[i].detachedID)
+		}
+	}
+}
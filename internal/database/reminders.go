@@ -0,0 +1,72 @@
+package database
+
+import (
+	"log"
+	"time"
+
+	"event-planner-bot/internal/models"
+)
+
+// Неотменённые мероприятия и повторения серий, которые начинаются в промежутке (from, to]
+func (s *Storage) GetEventsStartingBetween(from, to time.Time) ([]models.Event, error) {
+	query := `
+    SELECT ` + eventColumns + `
+    FROM events
+    WHERE date <= ? AND (date > ? OR recurrence != '') AND status != ?
+    ORDER BY date, id`
+
+	events, err := s.queryEvents(query, to.UTC(), from.UTC(), models.StatusCancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.expandRecurring(events, func(start, end time.Time) bool {
+		return start.After(from) && !start.After(to)
+	}, from, to)
+}
+
+// Кому напоминать о мероприятии: создатель и все, кто идёт или, возможно, придёт
+func (s *Storage) GetReminderRecipients(eventID int64) ([]int64, error) {
+	query := `
+    SELECT created_by FROM events WHERE id = ?
+    UNION
+    SELECT user_id FROM attendees
+    WHERE event_id = ? AND status IN (?, ?)`
+
+	rows, err := s.db.Query(query, eventID, eventID, models.AttendanceGoing, models.AttendanceMaybe)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipients []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, userID)
+	}
+	return recipients, rows.Err()
+}
+
+// Отметка об отправке напоминания о мероприятии, которое начинается в start
+// (у серии - о конкретном повторении). Возвращает false, если такое
+// напоминание уже было отправлено, - тогда отправлять повторно не нужно
+func (s *Storage) ClaimReminder(eventID int64, start time.Time, userID int64, offset time.Duration) (bool, error) {
+	res, err := s.db.Exec(`
+    INSERT OR IGNORE INTO sent_reminders (event_id, occurrence, user_id, offset_minutes)
+    VALUES (?, ?, ?, ?)`, eventID, start.Unix(), userID, int64(offset/time.Minute))
+	if err != nil {
+		return false, err
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if inserted > 0 {
+		log.Printf("Напоминание за %s о мероприятии %d для %d", offset, eventID, userID)
+	}
+	return inserted > 0, nil
+}
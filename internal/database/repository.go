@@ -2,12 +2,21 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"log"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"event-planner-bot/internal/models"
+)
 
-	_ "github.com/mattn/go-sqlite3"
+var (
+	// Пользователь не найден
+	ErrUserNotFound = errors.New("пользователь не найден")
+	// Попытка снять права с последнего админа
+	ErrLastAdmin = errors.New("нельзя снять права с последнего админа")
 )
 
 // Хранилище для работы с БД
@@ -24,8 +33,10 @@ func NewStorage(dbPath string) (*Storage, error) {
 		return nil, err
 	}
 
-	// 2. Открытие файла базы данных
-	db, err := sql.Open("sqlite3", dbPath)
+	// 2. Открытие файла базы данных.
+	// _txlock=immediate: транзакция сразу берёт блокировку на запись,
+	// поэтому параллельные записи на мероприятие выполняются по очереди
+	db, err := sql.Open(driverName, dbPath+"?_txlock=immediate&_busy_timeout=5000")
 	if err != nil {
 		return nil, err
 	}
@@ -65,12 +76,83 @@ func createTables(db *sql.DB) error {
         title TEXT NOT NULL,
         description TEXT,
         date TIMESTAMP NOT NULL,
+        end_date TIMESTAMP,
+        all_day BOOLEAN NOT NULL DEFAULT FALSE,
+        timezone TEXT NOT NULL DEFAULT '',
+        recurrence TEXT NOT NULL DEFAULT '',
         location TEXT,
+        capacity INTEGER NOT NULL DEFAULT 0,
+        status TEXT NOT NULL DEFAULT 'planned',
         created_by INTEGER NOT NULL,
+        chat_id INTEGER NOT NULL DEFAULT 0,
         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
         updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
     );`
 
+	// Незавершённые диалоги с пользователями
+	createSessionsTable := `
+    CREATE TABLE IF NOT EXISTS sessions (
+        chat_id INTEGER NOT NULL,
+        user_id INTEGER NOT NULL,
+        kind TEXT NOT NULL,
+        step TEXT NOT NULL,
+        data TEXT NOT NULL DEFAULT '{}',
+        expires_at TIMESTAMP NOT NULL,
+        PRIMARY KEY (chat_id, user_id)
+    );`
+
+	// Ответы пользователей на мероприятия
+	createAttendeesTable := `
+    CREATE TABLE IF NOT EXISTS attendees (
+        event_id INTEGER NOT NULL,
+        user_id INTEGER NOT NULL,
+        status TEXT NOT NULL,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (event_id, user_id)
+    );`
+
+	// Личные настройки пользователей
+	createUserSettingsTable := `
+    CREATE TABLE IF NOT EXISTS user_settings (
+        user_id INTEGER PRIMARY KEY,
+        timezone TEXT NOT NULL DEFAULT '',
+        language TEXT NOT NULL DEFAULT '',
+        reminders TEXT NOT NULL DEFAULT '',
+        digest TEXT NOT NULL DEFAULT '',
+        privacy TEXT NOT NULL DEFAULT '',
+        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    );`
+
+	// Отправленные сводки мероприятий, по одной за период
+	createSentDigestsTable := `
+    CREATE TABLE IF NOT EXISTS sent_digests (
+        user_id INTEGER NOT NULL,
+        period TEXT NOT NULL,
+        sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (user_id, period)
+    );`
+
+	// Отправленные напоминания, чтобы не слать их повторно после перезапуска
+	createSentRemindersTable := `
+    CREATE TABLE IF NOT EXISTS sent_reminders (
+        event_id INTEGER NOT NULL,
+        occurrence INTEGER NOT NULL DEFAULT 0,
+        user_id INTEGER NOT NULL,
+        offset_minutes INTEGER NOT NULL,
+        sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (event_id, occurrence, user_id, offset_minutes)
+    );`
+
+	// Пропущенные и отделённые повторения повторяющихся мероприятий
+	createEventExceptionsTable := `
+    CREATE TABLE IF NOT EXISTS event_exceptions (
+        event_id INTEGER NOT NULL,
+        occurrence_date TIMESTAMP NOT NULL,
+        detached_id INTEGER NOT NULL DEFAULT 0,
+        PRIMARY KEY (event_id, occurrence_date)
+    );`
+
 	// Исправлено: правильные имена переменных
 	if _, err := db.Exec(createUsersTable); err != nil {
 		return err
@@ -80,10 +162,254 @@ func createTables(db *sql.DB) error {
 		return err
 	}
 
+	// Колонки, добавленные после первой версии схемы
+	if err := addColumnIfMissing(db, "events", "capacity", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+
+	if err := addColumnIfMissing(db, "events", "status", "TEXT NOT NULL DEFAULT 'planned'"); err != nil {
+		return err
+	}
+
+	if err := addColumnIfMissing(db, "events", "end_date", "TIMESTAMP"); err != nil {
+		return err
+	}
+
+	if err := addColumnIfMissing(db, "events", "all_day", "BOOLEAN NOT NULL DEFAULT FALSE"); err != nil {
+		return err
+	}
+
+	if err := addColumnIfMissing(db, "events", "timezone", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+
+	if err := addColumnIfMissing(db, "events", "recurrence", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+
+	if err := addColumnIfMissing(db, "events", "chat_id", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+
+	// До поддержки групп мероприятия создавались в личном чате с ботом,
+	// а ID личного чата совпадает с ID пользователя
+	if _, err := db.Exec(`UPDATE events SET chat_id = created_by WHERE chat_id = 0`); err != nil {
+		return err
+	}
+
+	if err := backfillEventEndDates(db); err != nil {
+		return err
+	}
+
+	if _, err := db.Exec(createSessionsTable); err != nil {
+		return err
+	}
+
+	if _, err := db.Exec(createAttendeesTable); err != nil {
+		return err
+	}
+
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_attendees_user ON attendees(user_id)`); err != nil {
+		return err
+	}
+
+	if err := migrateSentReminders(db); err != nil {
+		return err
+	}
+
+	if _, err := db.Exec(createSentRemindersTable); err != nil {
+		return err
+	}
+
+	if _, err := db.Exec(createEventExceptionsTable); err != nil {
+		return err
+	}
+
+	if err := addColumnIfMissing(db, "event_exceptions", "detached_id", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+
+	if _, err := db.Exec(createUserSettingsTable); err != nil {
+		return err
+	}
+
+	if err := addColumnIfMissing(db, "user_settings", "language", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+
+	for _, column := range []string{"reminders", "digest", "privacy"} {
+		if err := addColumnIfMissing(db, "user_settings", column, "TEXT NOT NULL DEFAULT ''"); err != nil {
+			return err
+		}
+	}
+
+	if _, err := db.Exec(createSentDigestsTable); err != nil {
+		return err
+	}
+
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_events_date ON events(date)`); err != nil {
+		return err
+	}
+
+	if err := migrateEventsSearch(db); err != nil {
+		return err
+	}
+
 	log.Println("Таблицы созданы")
 	return nil
 }
 
+// Добавление колонки в существующую таблицу, если её ещё нет
+func addColumnIfMissing(db *sql.DB, table, column, definition string) error {
+	exists, err := hasColumn(db, table, column)
+	if err != nil || exists {
+		return err
+	}
+
+	log.Printf("Добавление колонки %s.%s", table, column)
+	_, err = db.Exec(`ALTER TABLE ` + table + ` ADD COLUMN ` + column + ` ` + definition)
+	return err
+}
+
+// Есть ли в таблице колонка; для несуществующей таблицы - false
+func hasColumn(db *sql.DB, table, column string) (bool, error) {
+	rows, err := db.Query(`PRAGMA table_info(` + table + `)`)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   bool
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
+// В старой схеме sent_reminders не различала повторения мероприятия.
+// Колонка occurrence входит в первичный ключ, поэтому таблица пересоздаётся;
+// для уже отправленных напоминаний occurrence - начало мероприятия
+func migrateSentReminders(db *sql.DB) error {
+	var tables int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'sent_reminders'`).Scan(&tables); err != nil {
+		return err
+	}
+	if tables == 0 {
+		return nil
+	}
+
+	exists, err := hasColumn(db, "sent_reminders", "occurrence")
+	if err != nil || exists {
+		return err
+	}
+
+	log.Println("Пересоздание таблицы sent_reminders")
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+    SELECT r.event_id, r.user_id, r.offset_minutes, r.sent_at, e.date
+    FROM sent_reminders r
+    JOIN events e ON e.id = r.event_id`)
+	if err != nil {
+		return err
+	}
+
+	type sentReminder struct {
+		eventID, userID, offset int64
+		sentAt, date            time.Time
+	}
+	var sent []sentReminder
+	for rows.Next() {
+		var r sentReminder
+		if err := rows.Scan(&r.eventID, &r.userID, &r.offset, &r.sentAt, &r.date); err != nil {
+			rows.Close()
+			return err
+		}
+		sent = append(sent, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DROP TABLE sent_reminders`); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+    CREATE TABLE sent_reminders (
+        event_id INTEGER NOT NULL,
+        occurrence INTEGER NOT NULL DEFAULT 0,
+        user_id INTEGER NOT NULL,
+        offset_minutes INTEGER NOT NULL,
+        sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (event_id, occurrence, user_id, offset_minutes)
+    )`)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range sent {
+		_, err := tx.Exec(`
+        INSERT INTO sent_reminders (event_id, occurrence, user_id, offset_minutes, sent_at)
+        VALUES (?, ?, ?, ?, ?)`, r.eventID, r.date.Unix(), r.userID, r.offset, r.sentAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Заполнение времени окончания у мероприятий, созданных до его появления
+func backfillEventEndDates(db *sql.DB) error {
+	rows, err := db.Query(`SELECT id, date FROM events WHERE end_date IS NULL`)
+	if err != nil {
+		return err
+	}
+
+	ends := make(map[int64]time.Time)
+	for rows.Next() {
+		var id int64
+		var start time.Time
+		if err := rows.Scan(&id, &start); err != nil {
+			rows.Close()
+			return err
+		}
+		ends[id] = start.Add(models.DefaultEventDuration).UTC()
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, end := range ends {
+		if _, err := db.Exec(`UPDATE events SET end_date = ? WHERE id = ?`, end, id); err != nil {
+			return err
+		}
+	}
+	if len(ends) > 0 {
+		log.Printf("Заполнено время окончания у %d мероприятий", len(ends))
+	}
+	return nil
+}
+
 // Создание пользователя (CreateUser вместо AddUser)
 func (s *Storage) CreateUser(user *models.User) error {
 	log.Printf("Создание пользователя: %s", user.Username)
@@ -132,34 +458,434 @@ func (s *Storage) GetUserByTelegramID(telegramID int64) (*models.User, error) {
 	return user, err
 }
 
+// Поиск пользователя по имени в телеграмме (без @, без учёта регистра)
+func (s *Storage) GetUserByUsername(username string) (*models.User, error) {
+	log.Printf("Поиск пользователя @%s", username)
+
+	user := &models.User{}
+
+	query := `
+    SELECT id, telegram_id, username, first_name, last_name, is_admin, created_at
+    FROM users
+    WHERE username = ? COLLATE NOCASE`
+
+	err := s.db.QueryRow(query, username).Scan(
+		&user.ID,
+		&user.TelegramID,
+		&user.Username,
+		&user.Name,
+		&user.Surname,
+		&user.IsAdmin,
+		&user.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return user, err
+}
+
+// Назначение пользователя админом
+func (s *Storage) PromoteAdmin(telegramID int64) error {
+	log.Printf("Назначение админом пользователя ID: %d", telegramID)
+
+	res, err := s.db.Exec(`UPDATE users SET is_admin = TRUE WHERE telegram_id = ?`, telegramID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// Снятие прав админа. Последнего админа разжаловать нельзя
+func (s *Storage) DemoteAdmin(telegramID int64) error {
+	log.Printf("Снятие прав админа с пользователя ID: %d", telegramID)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var isAdmin bool
+	err = tx.QueryRow(`SELECT is_admin FROM users WHERE telegram_id = ?`, telegramID).Scan(&isAdmin)
+	if err == sql.ErrNoRows {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return nil
+	}
+
+	var admins int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM users WHERE is_admin`).Scan(&admins); err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return ErrLastAdmin
+	}
+
+	if _, err := tx.Exec(`UPDATE users SET is_admin = FALSE WHERE telegram_id = ?`, telegramID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Получение списка пользователей постранично
+func (s *Storage) GetUsers(limit, offset int) ([]models.User, error) {
+	log.Printf("Получение пользователей: limit=%d offset=%d", limit, offset)
+
+	query := `
+    SELECT id, telegram_id, username, first_name, last_name, is_admin, created_at
+    FROM users
+    ORDER BY id
+    LIMIT ? OFFSET ?`
+
+	rows, err := s.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []models.User
+
+	for rows.Next() {
+		var user models.User
+		err := rows.Scan(
+			&user.ID,
+			&user.TelegramID,
+			&user.Username,
+			&user.Name,
+			&user.Surname,
+			&user.IsAdmin,
+			&user.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// Количество пользователей
+func (s *Storage) CountUsers() (int, error) {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM users`).Scan(&count)
+	return count, err
+}
+
+// Сводная статистика по пользователям и мероприятиям
+func (s *Storage) GetStats(now time.Time) (*models.Stats, error) {
+	log.Println("Получение статистики")
+
+	stats := &models.Stats{}
+
+	err := s.db.QueryRow(`
+    SELECT COUNT(*), COALESCE(SUM(is_admin), 0)
+    FROM users`).Scan(&stats.Users, &stats.Admins)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.QueryRow(`
+    SELECT COUNT(*),
+           COALESCE(SUM(CASE WHEN date >= ? THEN 1 ELSE 0 END), 0)
+    FROM events`, now.UTC()).Scan(&stats.Events, &stats.Upcoming)
+	if err != nil {
+		return nil, err
+	}
+	stats.Past = stats.Events - stats.Upcoming
+
+	// Самые активные создатели мероприятий
+	query := `
+    SELECT e.created_by, COALESCE(u.username, ''), COALESCE(u.first_name, ''), COUNT(*) AS cnt
+    FROM events e
+    LEFT JOIN users u ON u.telegram_id = e.created_by
+    GROUP BY e.created_by
+    ORDER BY cnt DESC, e.created_by
+    LIMIT 10`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var creator models.CreatorStat
+		if err := rows.Scan(&creator.TelegramID, &creator.Username, &creator.Name, &creator.Events); err != nil {
+			return nil, err
+		}
+		stats.Creators = append(stats.Creators, creator)
+	}
+
+	return stats, rows.Err()
+}
+
 // Создание мероприятия
 func (s *Storage) CreateEvent(event *models.Event) error {
 	log.Printf("Создание мероприятия: %s", event.Title)
+	return insertEvent(s.db, event)
+}
 
+// Вставка мероприятия; event.ID заполняется
+func insertEvent(db execer, event *models.Event) error {
 	query := `
-    INSERT INTO events (title, description, date, location, created_by)
-    VALUES (?, ?, ?, ?, ?)`
+    INSERT INTO events (title, description, date, end_date, all_day, timezone, recurrence, location, capacity, created_by, chat_id)
+    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	_, err := s.db.Exec(query,
+	res, err := db.Exec(query,
 		event.Title,
 		event.Description,
-		event.EventDate, // Внимание: поле EventDate, а не Date!
+		event.EventDate.UTC(), // Внимание: поле EventDate, а не Date! Храним в UTC
+		event.EndTime().UTC(),
+		event.AllDay,
+		event.Timezone,
+		event.Recurrence,
 		event.Location,
-		event.CreatedBy)
+		event.Capacity,
+		event.CreatedBy,
+		event.ChatID)
+	if err != nil {
+		return err
+	}
 
+	event.ID, err = res.LastInsertId()
 	return err
 }
 
-// Получение всех мероприятий
-func (s *Storage) GetAllEvents() ([]models.Event, error) {
-	log.Println("Получение всех мероприятий")
+// Колонки мероприятия в порядке, который ожидает scanEvent
+const eventColumns = `id, title, description, date, end_date, all_day, timezone, recurrence, location, capacity, status, created_by, chat_id, created_at, updated_at`
+
+// Общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEvent(row rowScanner, event *models.Event) error {
+	var endDate sql.NullTime
+
+	err := row.Scan(
+		&event.ID,
+		&event.Title,
+		&event.Description,
+		&event.EventDate, // Внимание: поле EventDate!
+		&endDate,
+		&event.AllDay,
+		&event.Timezone,
+		&event.Recurrence,
+		&event.Location,
+		&event.Capacity,
+		&event.Status,
+		&event.CreatedBy,
+		&event.ChatID,
+		&event.CreatedAt,
+		&event.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
 
+	event.EndDate = endDate.Time
+	return nil
+}
+
+// Получение всех мероприятий, которые идут в промежутке [from, to).
+// Повторяющиеся мероприятия разворачиваются в отдельные повторения
+func (s *Storage) GetAllEvents(from, to time.Time) ([]models.Event, error) {
+	return s.FindEvents(EventFilter{From: from, To: to})
+}
+
+// Отбор мероприятий для списков
+type EventFilter struct {
+	From, To  time.Time // мероприятие идёт хотя бы часть промежутка [From, To)
+	EndedBy   time.Time // нулевое - любые, иначе только закончившиеся к этому моменту
+	CreatedBy int64     // 0 - любой создатель
+	ChatID    int64     // 0 - созданные в любом чате
+	Newest    bool      // сначала поздние; для страниц FindEventsPage
+}
+
+// Условия filter для WHERE. У серии end_date - окончание первого повторения,
+// поэтому для серий отбираются все, начавшиеся до конца промежутка
+func (f *EventFilter) where() ([]string, []interface{}) {
+	conditions := []string{`date < ?`, `(end_date > ? OR recurrence != '')`}
+	args := []interface{}{f.To.UTC(), f.From.UTC()}
+
+	if !f.EndedBy.IsZero() {
+		conditions = append(conditions, `(end_date <= ? OR recurrence != '')`)
+		args = append(args, f.EndedBy.UTC())
+	}
+	if f.CreatedBy != 0 {
+		conditions = append(conditions, `created_by = ?`)
+		args = append(args, f.CreatedBy)
+	}
+	if f.ChatID != 0 {
+		conditions = append(conditions, `chat_id = ?`)
+		args = append(args, f.ChatID)
+	}
+	return conditions, args
+}
+
+// Проверка повторения серии по промежутку и EndedBy
+func (f *EventFilter) keep(start, end time.Time) bool {
+	if !f.EndedBy.IsZero() && end.After(f.EndedBy) {
+		return false
+	}
+	return start.Before(f.To) && end.After(f.From)
+}
+
+// Мероприятия и повторения серий, отобранные по filter, по времени начала.
+// Условия на дату проверяются в SQL по индексу idx_events_date
+func (s *Storage) FindEvents(filter EventFilter) ([]models.Event, error) {
+	log.Printf("Получение мероприятий с %s по %s", filter.From.UTC(), filter.To.UTC())
+
+	conditions, args := filter.where()
 	query := `
-    SELECT id, title, description, date, location, created_by, created_at, updated_at
+    SELECT ` + eventColumns + `
     FROM events
-    ORDER BY date`
+    WHERE ` + strings.Join(conditions, " AND ") + `
+    ORDER BY date, id`
 
-	rows, err := s.db.Query(query)
+	events, err := s.queryEvents(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err = s.expandRecurring(events, filter.keep, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Найдено %d мероприятий", len(events))
+	return events, nil
+}
+
+// Позиция в списке: начало и ID мероприятия на границе страницы
+type EventCursor struct {
+	Date time.Time
+	ID   int64
+}
+
+// Сравнение позиций по (начало, ID)
+func (c EventCursor) before(other EventCursor) bool {
+	if !c.Date.Equal(other.Date) {
+		return c.Date.Before(other.Date)
+	}
+	return c.ID < other.ID
+}
+
+// Страница списка по ключу (date, id): до limit мероприятий сразу после
+// cursor в порядке списка, а при backward - сразу перед ним. Без cursor -
+// первая страница. Второй результат - есть ли мероприятия дальше в ту же сторону.
+// Обычные мероприятия читаются из SQL постранично, серии разворачиваются
+// и отбираются по тому же ключу
+func (s *Storage) FindEventsPage(filter EventFilter, cursor *EventCursor, backward bool, limit int) ([]models.Event, bool, error) {
+	// Порядок, в котором идём от cursor: по возрастанию, если список
+	// по возрастанию и листаем вперёд или список по убыванию и листаем назад
+	ascending := filter.Newest == backward
+
+	conditions, args := filter.where()
+	conditions = append(conditions, `recurrence = ''`)
+	if cursor != nil {
+		op := ">"
+		if !ascending {
+			op = "<"
+		}
+		conditions = append(conditions, `(date, id) `+op+` (?, ?)`)
+		args = append(args, cursor.Date.UTC(), cursor.ID)
+	}
+
+	order := "date, id"
+	if !ascending {
+		order = "date DESC, id DESC"
+	}
+	query := `
+    SELECT ` + eventColumns + `
+    FROM events
+    WHERE ` + strings.Join(conditions, " AND ") + `
+    ORDER BY ` + order + `
+    LIMIT ?`
+	args = append(args, limit+1)
+
+	events, err := s.queryEvents(query, args...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Серии целиком: их немного, а повторения всё равно считаются в Go
+	seriesConditions, seriesArgs := filter.where()
+	seriesConditions = append(seriesConditions, `recurrence != ''`)
+	series, err := s.queryEvents(`
+    SELECT `+eventColumns+`
+    FROM events
+    WHERE `+strings.Join(seriesConditions, " AND "), seriesArgs...)
+	if err != nil {
+		return nil, false, err
+	}
+	occurrences, err := s.expandRecurring(series, filter.keep, filter.From, filter.To)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, occurrence := range occurrences {
+		pos := EventCursor{Date: occurrence.EventDate, ID: occurrence.ID}
+		if cursor != nil && (ascending && !cursor.before(pos) || !ascending && !pos.before(*cursor)) {
+			continue
+		}
+		events = append(events, occurrence)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a := EventCursor{Date: events[i].EventDate, ID: events[i].ID}
+		b := EventCursor{Date: events[j].EventDate, ID: events[j].ID}
+		if ascending {
+			return a.before(b)
+		}
+		return b.before(a)
+	})
+
+	more := len(events) > limit
+	if more {
+		events = events[:limit]
+	}
+
+	// Страница всегда в порядке списка
+	if backward {
+		for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+			events[i], events[j] = events[j], events[i]
+		}
+	}
+	return events, more, nil
+}
+
+// Неотменённые мероприятия чата chatID, которые ещё не закончились к моменту now.
+// Серии не разворачиваются в повторения и попадают в результат целиком
+func (s *Storage) GetUpcomingEvents(now time.Time, chatID int64) ([]models.Event, error) {
+	log.Println("Получение предстоящих мероприятий")
+
+	query := `
+    SELECT ` + eventColumns + `
+    FROM events
+    WHERE (end_date > ? OR recurrence != '') AND status != ? AND chat_id = ?
+    ORDER BY date, id`
+
+	return s.queryEvents(query, now.UTC(), models.StatusCancelled, chatID)
+}
+
+// Выполнение запроса, который выбирает eventColumns
+func (s *Storage) queryEvents(query string, args ...interface{}) ([]models.Event, error) {
+	rows, err := s.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -169,24 +895,13 @@ func (s *Storage) GetAllEvents() ([]models.Event, error) {
 
 	for rows.Next() {
 		var event models.Event
-		err := rows.Scan(
-			&event.ID,
-			&event.Title,
-			&event.Description,
-			&event.EventDate, // Внимание: поле EventDate!
-			&event.Location,
-			&event.CreatedBy,
-			&event.CreatedAt,
-			&event.UpdatedAt,
-		)
-		if err != nil {
+		if err := scanEvent(rows, &event); err != nil {
 			return nil, err
 		}
 		events = append(events, event)
 	}
 
-	log.Printf("Найдено %d мероприятий", len(events))
-	return events, nil
+	return events, rows.Err()
 }
 
 // Получение мероприятия по ID
@@ -196,22 +911,11 @@ func (s *Storage) GetEventByID(id int64) (*models.Event, error) {
 	event := &models.Event{}
 
 	query := `
-    SELECT id, title, description, date, location, created_by, created_at, updated_at
+    SELECT ` + eventColumns + `
     FROM events
     WHERE id = ?`
 
-	row := s.db.QueryRow(query, id)
-
-	err := row.Scan(
-		&event.ID,
-		&event.Title,
-		&event.Description,
-		&event.EventDate, // Внимание: поле EventDate!
-		&event.Location,
-		&event.CreatedBy,
-		&event.CreatedAt,
-		&event.UpdatedAt,
-	)
+	err := scanEvent(s.db.QueryRow(query, id), event)
 
 	if err == sql.ErrNoRows {
 		log.Println("Мероприятие не найдено")
@@ -221,32 +925,93 @@ func (s *Storage) GetEventByID(id int64) (*models.Event, error) {
 	return event, err
 }
 
-// Обновление мероприятия
+// Обновление мероприятия.
+// Если дата изменилась, отправленные напоминания сбрасываются,
+// чтобы напомнить заново относительно новой даты
 func (s *Storage) UpdateEvent(event *models.Event) error {
 	log.Printf("Обновление мероприятия ID: %d", event.ID)
 
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var old models.Event
+	if err := scanEvent(tx.QueryRow(`SELECT `+eventColumns+` FROM events WHERE id = ?`, event.ID), &old); err != nil {
+		return err
+	}
+	oldDate := old.EventDate
+
+	if err := moveExceptions(tx, &old, event); err != nil {
+		return err
+	}
+
 	query := `
     UPDATE events
-    SET title = ?, description = ?, date = ?, location = ?, updated_at = CURRENT_TIMESTAMP
+    SET title = ?, description = ?, date = ?, end_date = ?, all_day = ?, timezone = ?,
+        recurrence = ?, location = ?, capacity = ?, updated_at = CURRENT_TIMESTAMP
     WHERE id = ?`
 
-	_, err := s.db.Exec(query,
+	_, err = tx.Exec(query,
 		event.Title,
 		event.Description,
-		event.EventDate, // Внимание: поле EventDate!
+		event.EventDate.UTC(), // Внимание: поле EventDate! Храним в UTC
+		event.EndTime().UTC(),
+		event.AllDay,
+		event.Timezone,
+		event.Recurrence,
 		event.Location,
+		event.Capacity,
 		event.ID)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if !oldDate.Equal(event.EventDate) {
+		log.Printf("Дата мероприятия %d изменилась, напоминания будут отправлены заново", event.ID)
+		if _, err := tx.Exec(`DELETE FROM sent_reminders WHERE event_id = ?`, event.ID); err != nil {
+			return err
+		}
+
+		// Статус пересчитает UpdateEventStatuses относительно новой даты
+		_, err := tx.Exec(`UPDATE events SET status = ? WHERE id = ? AND status != ?`,
+			models.StatusPlanned, event.ID, models.StatusCancelled)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 // Удаление мероприятия
 func (s *Storage) DeleteEvent(id int64) error {
 	log.Printf("Удаление мероприятия ID: %d", id)
 
-	query := `DELETE FROM events WHERE id = ?`
-	_, err := s.db.Exec(query, id)
-	return err
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM attendees WHERE event_id = ?`, id); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM sent_reminders WHERE event_id = ?`, id); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM event_exceptions WHERE event_id = ?`, id); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM events WHERE id = ?`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // Закрытие соединения
@@ -0,0 +1,247 @@
+package database
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"event-planner-bot/internal/models"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// Драйвер SQLite с функцией ulower: встроенная lower() меняет регистр
+// только у латиницы
+const driverName = "sqlite3_planner"
+
+// Сколько слов запроса учитывать
+const maxSearchTerms = 8
+
+func init() {
+	sql.Register(driverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("ulower", normalizeText, true)
+		},
+	})
+}
+
+// Текст для поиска: нижний регистр, «ё» как «е»
+func normalizeText(s string) string {
+	return strings.ReplaceAll(strings.ToLower(s), "ё", "е")
+}
+
+// Окончания, которые отбрасываются у слов запроса, чтобы «встречи»
+// находило «встреча», а «лекцию» - «лекция»
+const wordEndings = "аеиоуыэюяйь"
+
+// Слова запроса в нормализованном виде с отброшенными окончаниями
+func searchTerms(query string) []string {
+	words := strings.FieldsFunc(normalizeText(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var terms []string
+	for _, word := range words {
+		for i := 0; i < 2 && utf8.RuneCountInString(word) > 4; i++ {
+			last, size := utf8.DecodeLastRuneInString(word)
+			if !strings.ContainsRune(wordEndings, last) {
+				break
+			}
+			word = word[:len(word)-size]
+		}
+		terms = append(terms, word)
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// Полнотекстовый индекс мероприятий. Текст в индексе хранится с «ё»,
+// заменённой на «е»; регистр без учёта языка снимает токенизатор unicode61
+const createEventsSearchTable = `
+    CREATE VIRTUAL TABLE IF NOT EXISTS events_fts USING fts5(
+        title, description, location,
+        tokenize = 'unicode61 remove_diacritics 2'
+    );`
+
+// Текст колонки для индекса
+func ftsText(column string) string {
+	return `replace(replace(coalesce(` + column + `, ''), 'ё', 'е'), 'Ё', 'Е')`
+}
+
+var ftsValues = `(new.id, ` + ftsText("new.title") + `, ` + ftsText("new.description") + `, ` + ftsText("new.location") + `)`
+
+// Триггеры, которые поддерживают индекс при CreateEvent, UpdateEvent и DeleteEvent
+var eventsSearchTriggers = map[string]string{
+	"events_fts_insert": `
+    CREATE TRIGGER events_fts_insert AFTER INSERT ON events BEGIN
+        INSERT INTO events_fts (rowid, title, description, location) VALUES ` + ftsValues + `;
+    END;`,
+	"events_fts_update": `
+    CREATE TRIGGER events_fts_update AFTER UPDATE OF title, description, location ON events BEGIN
+        DELETE FROM events_fts WHERE rowid = old.id;
+        INSERT INTO events_fts (rowid, title, description, location) VALUES ` + ftsValues + `;
+    END;`,
+	"events_fts_delete": `
+    CREATE TRIGGER events_fts_delete AFTER DELETE ON events BEGIN
+        DELETE FROM events_fts WHERE rowid = old.id;
+    END;`,
+}
+
+// Создание поискового индекса и триггеров. Если триггеров не было (новая база
+// или бот до этого работал без FTS5), индекс заполняется заново.
+// Без FTS5 триггеры удаляются, иначе любая запись в events завершится ошибкой
+func migrateEventsSearch(db *sql.DB) error {
+	if !fullTextSearch {
+		log.Println("SQLite собран без FTS5 (нет тега sqlite_fts5): поиск мероприятий работает через LIKE без индекса")
+		for name := range eventsSearchTriggers {
+			if _, err := db.Exec(`DROP TRIGGER IF EXISTS ` + name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var triggers int
+	err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name LIKE 'events_fts_%'`).Scan(&triggers)
+	if err != nil {
+		return err
+	}
+	if triggers == len(eventsSearchTriggers) {
+		return nil
+	}
+
+	log.Println("Построение поискового индекса мероприятий")
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(createEventsSearchTable); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM events_fts`); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+    INSERT INTO events_fts (rowid, title, description, location)
+    SELECT id, ` + ftsText("title") + `, ` + ftsText("description") + `, ` + ftsText("location") + `
+    FROM events`)
+	if err != nil {
+		return err
+	}
+
+	for name, trigger := range eventsSearchTriggers {
+		if _, err := tx.Exec(`DROP TRIGGER IF EXISTS ` + name); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(trigger); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Поиск мероприятий по названию, описанию и месту. Сначала самые
+// подходящие: совпадение в названии весит больше, чем в месте и описании.
+// Ищутся мероприятия, созданные в чате chatID.
+// Возвращает страницу результатов и общее число найденных
+func (s *Storage) SearchEvents(query string, chatID int64, limit, offset int) ([]models.Event, int, error) {
+	log.Printf("Поиск мероприятий: %q", query)
+
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, 0, nil
+	}
+
+	if fullTextSearch {
+		return s.searchFTS(terms, chatID, limit, offset)
+	}
+	return s.searchLike(terms, chatID, limit, offset)
+}
+
+func (s *Storage) searchFTS(terms []string, chatID int64, limit, offset int) ([]models.Event, int, error) {
+	// Каждое слово - префикс в кавычках, все слова обязательны
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = `"` + term + `"*`
+	}
+	match := strings.Join(parts, " ")
+
+	var total int
+	err := s.db.QueryRow(`
+    SELECT COUNT(*)
+    FROM events_fts
+    JOIN events e ON e.id = events_fts.rowid
+    WHERE events_fts MATCH ? AND e.chat_id = ?`, match, chatID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+    SELECT ` + prefixColumns("e", eventColumns) + `
+    FROM events_fts
+    JOIN events e ON e.id = events_fts.rowid
+    WHERE events_fts MATCH ? AND e.chat_id = ?
+    ORDER BY bm25(events_fts, 10.0, 1.0, 3.0), e.date DESC, e.id
+    LIMIT ? OFFSET ?`
+
+	events, err := s.queryEvents(query, match, chatID, limit, offset)
+	return events, total, err
+}
+
+// Поиск без FTS5: каждое слово должно встречаться в названии, описании
+// или месте; выше те, у кого больше слов совпало в названии
+func (s *Storage) searchLike(terms []string, chatID int64, limit, offset int) ([]models.Event, int, error) {
+	var conditions, ranks []string
+	var args, rankArgs []interface{}
+	for _, term := range terms {
+		pattern := "%" + escapeLike(term) + "%"
+		conditions = append(conditions,
+			`(ulower(title) LIKE ? ESCAPE '\' OR ulower(coalesce(description, '')) LIKE ? ESCAPE '\' OR ulower(coalesce(location, '')) LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern, pattern)
+		ranks = append(ranks, `(ulower(title) LIKE ? ESCAPE '\')`)
+		rankArgs = append(rankArgs, pattern)
+	}
+	conditions = append(conditions, `chat_id = ?`)
+	args = append(args, chatID)
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM events WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+    SELECT ` + eventColumns + `
+    FROM events
+    WHERE ` + where + `
+    ORDER BY ` + strings.Join(ranks, " + ") + ` DESC, date DESC, id
+    LIMIT ? OFFSET ?`
+
+	args = append(args, rankArgs...)
+	args = append(args, limit, offset)
+	events, err := s.queryEvents(query, args...)
+	return events, total, err
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// Колонки с префиксом таблицы: "e.id, e.title, ..."
+func prefixColumns(prefix, columns string) string {
+	names := strings.Split(columns, ", ")
+	for i, name := range names {
+		names[i] = prefix + "." + name
+	}
+	return strings.Join(names, ", ")
+}
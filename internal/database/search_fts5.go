@@ -0,0 +1,6 @@
+//go:build sqlite_fts5 || fts5
+
+package database
+
+// Собрано с FTS5: go build -tags sqlite_fts5
+const fullTextSearch = true
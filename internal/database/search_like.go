@@ -0,0 +1,6 @@
+//go:build !sqlite_fts5 && !fts5
+
+package database
+
+// Без тега sqlite_fts5 модуля FTS5 в SQLite нет - поиск идёт через LIKE
+const fullTextSearch = false
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"event-planner-bot/internal/models"
+)
+
+// Получение активного (не истёкшего) диалога
+func (s *Storage) GetSession(chatID, userID int64) (*models.Session, error) {
+	session := &models.Session{ChatID: chatID, UserID: userID}
+
+	query := `
+    SELECT kind, step, data, expires_at
+    FROM sessions
+    WHERE chat_id = ? AND user_id = ? AND expires_at > ?`
+
+	var data string
+	err := s.db.QueryRow(query, chatID, userID, time.Now().UTC()).Scan(
+		&session.Kind,
+		&session.Step,
+		&data,
+		&session.ExpiresAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(data), &session.Data); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Сохранение диалога (создание или обновление)
+func (s *Storage) SaveSession(session *models.Session) error {
+	data, err := json.Marshal(session.Data)
+	if err != nil {
+		return err
+	}
+
+	query := `
+    INSERT INTO sessions (chat_id, user_id, kind, step, data, expires_at)
+    VALUES (?, ?, ?, ?, ?, ?)
+    ON CONFLICT (chat_id, user_id) DO UPDATE SET
+        kind = excluded.kind,
+        step = excluded.step,
+        data = excluded.data,
+        expires_at = excluded.expires_at`
+
+	_, err = s.db.Exec(query,
+		session.ChatID,
+		session.UserID,
+		session.Kind,
+		session.Step,
+		string(data),
+		session.ExpiresAt.UTC())
+
+	return err
+}
+
+// Удаление диалога
+func (s *Storage) DeleteSession(chatID, userID int64) error {
+	_, err := s.db.Exec(`DELETE FROM sessions WHERE chat_id = ? AND user_id = ?`, chatID, userID)
+	return err
+}
+
+// Удаление заброшенных диалогов
+func (s *Storage) DeleteExpiredSessions(now time.Time) (int64, error) {
+	res, err := s.db.Exec(`DELETE FROM sessions WHERE expires_at <= ?`, now.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if deleted > 0 {
+		log.Printf("Удалено истёкших диалогов: %d", deleted)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"database/sql"
+	"log"
+
+	"event-planner-bot/internal/models"
+)
+
+const settingsColumns = `user_id, timezone, language, reminders, digest, privacy, updated_at`
+
+func scanUserSettings(row rowScanner, settings *models.UserSettings) error {
+	return row.Scan(&settings.UserID, &settings.Timezone, &settings.Language,
+		&settings.Reminders, &settings.Digest, &settings.Privacy, &settings.UpdatedAt)
+}
+
+// Настройки пользователя; если он ничего не менял - настройки по умолчанию
+func (s *Storage) GetUserSettings(userID int64) (*models.UserSettings, error) {
+	settings := &models.UserSettings{UserID: userID}
+
+	err := scanUserSettings(s.db.QueryRow(`
+    SELECT `+settingsColumns+`
+    FROM user_settings
+    WHERE user_id = ?`, userID), settings)
+	if err == sql.ErrNoRows {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Сохранение настроек пользователя
+func (s *Storage) SaveUserSettings(settings *models.UserSettings) error {
+	log.Printf("Сохранение настроек пользователя %d", settings.UserID)
+
+	query := `
+    INSERT INTO user_settings (user_id, timezone, language, reminders, digest, privacy, updated_at)
+    VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+    ON CONFLICT (user_id) DO UPDATE SET
+        timezone = excluded.timezone,
+        language = excluded.language,
+        reminders = excluded.reminders,
+        digest = excluded.digest,
+        privacy = excluded.privacy,
+        updated_at = excluded.updated_at`
+
+	_, err := s.db.Exec(query, settings.UserID, settings.Timezone, settings.Language,
+		settings.Reminders, settings.Digest, settings.Privacy)
+	return err
+}
+
+// Настройки всех, кто подписан на сводку мероприятий
+func (s *Storage) GetDigestSubscribers() ([]models.UserSettings, error) {
+	rows, err := s.db.Query(`
+    SELECT `+settingsColumns+`
+    FROM user_settings
+    WHERE digest != ?`, models.DigestOff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscribers []models.UserSettings
+	for rows.Next() {
+		var settings models.UserSettings
+		if err := scanUserSettings(rows, &settings); err != nil {
+			return nil, err
+		}
+		subscribers = append(subscribers, settings)
+	}
+	return subscribers, rows.Err()
+}
+
+// Отметка об отправке сводки за период (день «2026-12-31» или неделя «2026-W53»).
+// false - сводка за этот период уже отправлена
+func (s *Storage) ClaimDigest(userID int64, period string) (bool, error) {
+	res, err := s.db.Exec(`
+    INSERT OR IGNORE INTO sent_digests (user_id, period)
+    VALUES (?, ?)`, userID, period)
+	if err != nil {
+		return false, err
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return inserted > 0, nil
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"log"
+	"time"
+
+	"event-planner-bot/internal/models"
+)
+
+// Перевод мероприятий planned -> ongoing -> ended по времени начала и окончания.
+// Отменённые и повторяющиеся мероприятия не трогаются: у серии нет одного
+// времени начала, её повторения получают статус серии
+func (s *Storage) UpdateEventStatuses(now time.Time) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+    UPDATE events SET status = ?, updated_at = CURRENT_TIMESTAMP
+    WHERE status IN (?, ?) AND end_date <= ? AND recurrence = ''`,
+		models.StatusEnded, models.StatusPlanned, models.StatusOngoing, now.UTC())
+	if err != nil {
+		return err
+	}
+	ended, _ := res.RowsAffected()
+
+	res, err = tx.Exec(`
+    UPDATE events SET status = ?, updated_at = CURRENT_TIMESTAMP
+    WHERE status = ? AND date <= ? AND recurrence = ''`,
+		models.StatusOngoing, models.StatusPlanned, now.UTC())
+	if err != nil {
+		return err
+	}
+	started, _ := res.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if started > 0 || ended > 0 {
+		log.Printf("Статусы мероприятий: началось %d, закончилось %d", started, ended)
+	}
+	return nil
+}
+
+// Отмена мероприятия без удаления
+func (s *Storage) CancelEvent(id int64) error {
+	log.Printf("Отмена мероприятия ID: %d", id)
+
+	_, err := s.db.Exec(`UPDATE events SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		models.StatusCancelled, id)
+	return err
+}
@@ -0,0 +1,609 @@
+// Разбор даты и времени, введённых человеком по-русски или по-английски:
+// «завтра в 19:00», «в пятницу 18:30», «25 декабря», «next monday 7pm»,
+// «через 2 часа», «31.12.2026»
+package dateparse
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ввод не удалось разобрать
+var ErrUnrecognized = errors.New("не удалось распознать дату")
+
+// Ввод можно понять по-разному; Options - возможные варианты
+type AmbiguousError struct {
+	Input   string
+	Options []Result
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("неоднозначная дата %q: %d варианта", e.Input, len(e.Options))
+}
+
+// Результат разбора
+type Result struct {
+	Time    time.Time // момент в часовом поясе пользователя; без времени - полночь
+	HasDate bool      // дата указана явно («завтра», «25.12»), а не выбрана по времени
+	HasTime bool      // указано время суток или сдвиг в минутах/часах
+}
+
+var (
+	clockPattern   = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+	dottedPattern  = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})(?:\.(\d{4}|\d{2}))?$`)
+	slashPattern   = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})(?:/(\d{4}|\d{2}))?$`)
+	isoPattern     = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})$`)
+	numberPattern  = regexp.MustCompile(`^\d{1,4}$`)
+	ordinalPattern = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th|-?го|-?е)$`)
+	gluedPattern   = regexp.MustCompile(`^(\d{1,2}(?::\d{2})?)(am|pm|a\.m\.|p\.m\.)$`)
+)
+
+// Слова, которые ничего не меняют
+var fillerWords = map[string]bool{
+	"on": true, "the": true, "of": true, "this": true,
+	"эту": true, "этот": true, "это": true, "г": true, "года": true,
+}
+
+// Предлоги перед временем: после них одиночное число - это час
+var atWords = map[string]bool{"в": true, "во": true, "at": true}
+
+var relativeDays = map[string]int{
+	"вчера": -1, "yesterday": -1,
+	"сегодня": 0, "today": 0,
+	"завтра": 1, "tomorrow": 1,
+	"послезавтра": 2,
+}
+
+var nextWords = map[string]bool{
+	"next": true, "следующий": true, "следующую": true, "следующее": true, "следующая": true,
+}
+
+var weekdays = map[string]time.Weekday{
+	"понедельник": time.Monday, "пн": time.Monday, "monday": time.Monday, "mon": time.Monday,
+	"вторник": time.Tuesday, "вт": time.Tuesday, "tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"среду": time.Wednesday, "среда": time.Wednesday, "ср": time.Wednesday, "wednesday": time.Wednesday, "wed": time.Wednesday,
+	"четверг": time.Thursday, "чт": time.Thursday, "thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"пятницу": time.Friday, "пятница": time.Friday, "пт": time.Friday, "friday": time.Friday, "fri": time.Friday,
+	"субботу": time.Saturday, "суббота": time.Saturday, "сб": time.Saturday, "saturday": time.Saturday, "sat": time.Saturday,
+	"воскресенье": time.Sunday, "вс": time.Sunday, "sunday": time.Sunday, "sun": time.Sunday,
+}
+
+var months = map[string]time.Month{
+	"января": time.January, "январь": time.January, "янв": time.January, "january": time.January, "jan": time.January,
+	"февраля": time.February, "февраль": time.February, "фев": time.February, "february": time.February, "feb": time.February,
+	"марта": time.March, "март": time.March, "мар": time.March, "march": time.March, "mar": time.March,
+	"апреля": time.April, "апрель": time.April, "апр": time.April, "april": time.April, "apr": time.April,
+	"мая": time.May, "май": time.May, "may": time.May,
+	"июня": time.June, "июнь": time.June, "июн": time.June, "june": time.June, "jun": time.June,
+	"июля": time.July, "июль": time.July, "июл": time.July, "july": time.July, "jul": time.July,
+	"августа": time.August, "август": time.August, "авг": time.August, "august": time.August, "aug": time.August,
+	"сентября": time.September, "сентябрь": time.September, "сен": time.September, "сент": time.September,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"октября": time.October, "октябрь": time.October, "окт": time.October, "october": time.October, "oct": time.October,
+	"ноября": time.November, "ноябрь": time.November, "ноя": time.November, "november": time.November, "nov": time.November,
+	"декабря": time.December, "декабрь": time.December, "дек": time.December, "december": time.December, "dec": time.December,
+}
+
+// Части суток после часа: «7 вечера», «7pm»
+const (
+	periodAM    = "am"
+	periodPM    = "pm"
+	periodDay   = "day"
+	periodNight = "night"
+)
+
+var periods = map[string]string{
+	"am": periodAM, "a.m.": periodAM, "утра": periodAM,
+	"pm": periodPM, "p.m.": periodPM, "вечера": periodPM,
+	"дня":  periodDay,
+	"ночи": periodNight,
+}
+
+var hourWords = map[string]bool{"час": true, "часа": true, "часов": true, "o'clock": true}
+
+// Единицы для «через N ...» / «in N ...»
+type unit struct {
+	duration time.Duration // для минут и часов
+	days     int
+	months   int
+}
+
+var units = map[string]unit{
+	"минуту": {duration: time.Minute}, "минуты": {duration: time.Minute}, "минут": {duration: time.Minute},
+	"мин": {duration: time.Minute}, "minute": {duration: time.Minute}, "minutes": {duration: time.Minute},
+	"min": {duration: time.Minute}, "mins": {duration: time.Minute},
+	"час": {duration: time.Hour}, "часа": {duration: time.Hour}, "часов": {duration: time.Hour},
+	"ч": {duration: time.Hour}, "hour": {duration: time.Hour}, "hours": {duration: time.Hour},
+	"hr": {duration: time.Hour}, "hrs": {duration: time.Hour},
+	"полчаса": {duration: 30 * time.Minute},
+	"день":    {days: 1}, "дня": {days: 1}, "дней": {days: 1}, "сутки": {days: 1}, "суток": {days: 1},
+	"day": {days: 1}, "days": {days: 1},
+	"неделю": {days: 7}, "недели": {days: 7}, "недель": {days: 7}, "week": {days: 7}, "weeks": {days: 7},
+	"месяц": {months: 1}, "месяца": {months: 1}, "месяцев": {months: 1}, "month": {months: 1}, "months": {months: 1},
+}
+
+// Что удалось найти во вводе
+type parser struct {
+	tokens  []string
+	pos     int
+	afterAt bool // предыдущее слово - «в» или «at»
+	invalid bool // противоречие или недопустимое значение
+
+	dateKind  int // сколько раз во вводе встретилась дата
+	dayOffset int
+	hasOffset bool
+
+	weekday    time.Weekday
+	hasWeekday bool
+	nextWeek   bool
+
+	year, month, day int // year == 0 - год не указан
+	hasCalendar      bool
+	swappable        bool // 03/04: день и месяц можно поменять местами
+
+	shift    unit // «через ...»
+	hasShift bool
+
+	hour, minute  int
+	hasTime       bool
+	ambiguousHour bool // «в 7» без «утра»/«вечера»
+}
+
+// Разбор ввода относительно момента now в часовом поясе loc
+func Parse(text string, now time.Time, loc *time.Location) (Result, error) {
+	p := &parser{tokens: tokenize(text)}
+	if len(p.tokens) == 0 {
+		return Result{}, ErrUnrecognized
+	}
+
+	for p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		if atWords[tok] {
+			p.pos++
+			p.afterAt = true
+			continue
+		}
+		if fillerWords[tok] {
+			p.pos++
+			continue
+		}
+
+		ok := p.parseRelativeDay() ||
+			p.parseShift() ||
+			p.parseWeekday() ||
+			p.parseNumericDate() ||
+			p.parseDayMonth() ||
+			p.parseMonthDay() ||
+			p.parseClock()
+		if !ok {
+			return Result{}, ErrUnrecognized
+		}
+		p.afterAt = false
+	}
+
+	if p.invalid || p.dateKind > 1 {
+		return Result{}, ErrUnrecognized
+	}
+	return p.resolve(text, now.In(loc))
+}
+
+// Разбиение на слова: нижний регистр, без запятых,
+// «7pm» и «25th» разделяются на число и суффикс
+func tokenize(text string) []string {
+	text = strings.ToLower(strings.NewReplacer(",", " ", ";", " ", "ё", "е").Replace(text))
+
+	var tokens []string
+	for _, tok := range strings.Fields(text) {
+		if m := gluedPattern.FindStringSubmatch(tok); m != nil {
+			tokens = append(tokens, m[1], m[2])
+			continue
+		}
+		if m := ordinalPattern.FindStringSubmatch(tok); m != nil {
+			tok = m[1]
+		}
+		// «дек.», «25.12.»; «a.m.» оставляем как есть
+		if periods[tok] == "" {
+			tok = strings.TrimSuffix(tok, ".")
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
+
+func (p *parser) peek(offset int) string {
+	if p.pos+offset < len(p.tokens) {
+		return p.tokens[p.pos+offset]
+	}
+	return ""
+}
+
+// «сегодня», «завтра», «послезавтра», «day after tomorrow»
+func (p *parser) parseRelativeDay() bool {
+	tok := p.peek(0)
+	if tok == "day" && p.peek(1) == "after" && p.peek(2) == "tomorrow" {
+		p.pos += 3
+		p.setOffset(2)
+		return true
+	}
+
+	offset, ok := relativeDays[tok]
+	if !ok {
+		return false
+	}
+	p.pos++
+	p.setOffset(offset)
+	return true
+}
+
+func (p *parser) setOffset(days int) {
+	p.dateKind++
+	p.dayOffset = days
+	p.hasOffset = true
+}
+
+// «через 2 часа», «через полчаса», «in an hour», «in 3 days»
+func (p *parser) parseShift() bool {
+	if tok := p.peek(0); tok != "через" && tok != "in" {
+		return false
+	}
+
+	n, i := 1, 1
+	switch next := p.peek(1); {
+	case numberPattern.MatchString(next):
+		n, _ = strconv.Atoi(next)
+		i++
+	case next == "a" || next == "an" || next == "one":
+		i++
+	case next == "half" && (p.peek(2) == "an" || p.peek(2) == "a") && p.peek(3) == "hour":
+		p.pos += 4
+		p.setShift(unit{duration: 30 * time.Minute})
+		return true
+	}
+
+	u, ok := units[p.peek(i)]
+	if !ok {
+		return false
+	}
+	p.pos += i + 1
+
+	u.duration *= time.Duration(n)
+	u.days *= n
+	u.months *= n
+	p.setShift(u)
+	return true
+}
+
+func (p *parser) setShift(u unit) {
+	p.dateKind++
+	p.shift = u
+	p.hasShift = true
+}
+
+// «в пятницу», «в следующую пятницу», «next monday», «fri»
+func (p *parser) parseWeekday() bool {
+	next := nextWords[p.peek(0)]
+	i := 0
+	if next {
+		i = 1
+	}
+
+	wd, ok := weekdays[p.peek(i)]
+	if !ok {
+		return false
+	}
+	p.pos += i + 1
+
+	p.dateKind++
+	p.weekday = wd
+	p.hasWeekday = true
+	p.nextWeek = next
+	return true
+}
+
+// «25.12», «25.12.2026», «2026-12-25», «12/25»
+func (p *parser) parseNumericDate() bool {
+	tok := p.peek(0)
+
+	var day, month, year string
+	swappable := false
+	if m := dottedPattern.FindStringSubmatch(tok); m != nil {
+		day, month, year = m[1], m[2], m[3]
+	} else if m := isoPattern.FindStringSubmatch(tok); m != nil {
+		year, month, day = m[1], m[2], m[3]
+	} else if m := slashPattern.FindStringSubmatch(tok); m != nil {
+		// Через косую черту пишут и ДД/ММ, и ММ/ДД
+		day, month, year = m[1], m[2], m[3]
+		swappable = true
+	} else {
+		return false
+	}
+	p.pos++
+
+	d, _ := strconv.Atoi(day)
+	m, _ := strconv.Atoi(month)
+	y := parseYear(year)
+
+	if swappable {
+		switch {
+		case d > 12 && m <= 12:
+			// Однозначно ДД/ММ
+		case m > 12 && d <= 12:
+			d, m = m, d
+		case d != m:
+			p.swappable = true
+		}
+	}
+
+	p.setCalendar(y, m, d)
+	return true
+}
+
+// «25 декабря», «25 декабря 2026», «25 dec»
+func (p *parser) parseDayMonth() bool {
+	if !numberPattern.MatchString(p.peek(0)) {
+		return false
+	}
+	month, ok := months[p.peek(1)]
+	if !ok {
+		return false
+	}
+
+	day, _ := strconv.Atoi(p.peek(0))
+	p.pos += 2
+	p.setCalendar(p.parseOptionalYear(), int(month), day)
+	return true
+}
+
+// «december 25», «dec 25 2026»
+func (p *parser) parseMonthDay() bool {
+	month, ok := months[p.peek(0)]
+	if !ok || !numberPattern.MatchString(p.peek(1)) || len(p.peek(1)) > 2 {
+		return false
+	}
+
+	day, _ := strconv.Atoi(p.peek(1))
+	p.pos += 2
+	p.setCalendar(p.parseOptionalYear(), int(month), day)
+	return true
+}
+
+// Год после даты, если он указан четырьмя цифрами
+func (p *parser) parseOptionalYear() int {
+	if tok := p.peek(0); len(tok) == 4 && numberPattern.MatchString(tok) {
+		p.pos++
+		return parseYear(tok)
+	}
+	return 0
+}
+
+func parseYear(text string) int {
+	if text == "" {
+		return 0
+	}
+	year, _ := strconv.Atoi(text)
+	if year < 100 {
+		year += 2000
+	}
+	return year
+}
+
+func (p *parser) setCalendar(year, month, day int) {
+	p.dateKind++
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		p.invalid = true
+	}
+	p.year, p.month, p.day = year, month, day
+	p.hasCalendar = true
+}
+
+// «19:00», «в 19», «7pm», «в 7 вечера», «полдень»
+func (p *parser) parseClock() bool {
+	tok := p.peek(0)
+
+	switch tok {
+	case "полдень", "noon":
+		p.pos++
+		p.setTime(12, 0, false)
+		return true
+	case "полночь", "midnight":
+		p.pos++
+		p.setTime(0, 0, false)
+		return true
+	}
+
+	var hour, minute int
+	withMinutes := false
+	if m := clockPattern.FindStringSubmatch(tok); m != nil {
+		hour, _ = strconv.Atoi(m[1])
+		minute, _ = strconv.Atoi(m[2])
+		withMinutes = true
+	} else if len(tok) <= 2 && numberPattern.MatchString(tok) &&
+		(p.afterAt || periods[p.peek(1)] != "" || hourWords[p.peek(1)]) {
+		hour, _ = strconv.Atoi(tok)
+	} else {
+		return false
+	}
+	p.pos++
+
+	if hourWords[p.peek(0)] {
+		p.pos++
+	}
+
+	period := periods[p.peek(0)]
+	if period != "" {
+		p.pos++
+	}
+
+	hour, ok := applyPeriod(hour, period)
+	if !ok || hour > 23 || minute > 59 {
+		p.invalid = true
+	}
+
+	// «в 7» может быть и утром, и вечером; «7:00» считаем 24-часовым форматом
+	ambiguous := period == "" && !withMinutes && hour >= 1 && hour <= 11
+	p.setTime(hour, minute, ambiguous)
+	return true
+}
+
+// Перевод часа с указанием части суток в 24-часовой формат
+func applyPeriod(hour int, period string) (int, bool) {
+	switch period {
+	case periodAM:
+		if hour > 12 {
+			return hour, false
+		}
+		if hour == 12 {
+			return 0, true
+		}
+	case periodPM:
+		if hour < 12 {
+			return hour + 12, true
+		}
+	case periodDay:
+		if hour >= 1 && hour <= 6 {
+			return hour + 12, true
+		}
+	case periodNight:
+		switch {
+		case hour == 12:
+			return 0, true
+		case hour >= 9 && hour <= 11:
+			return hour + 12, true
+		case hour > 5 && hour < 21:
+			return hour, false
+		}
+	}
+	return hour, true
+}
+
+func (p *parser) setTime(hour, minute int, ambiguous bool) {
+	if p.hasTime {
+		p.invalid = true
+	}
+	p.hour, p.minute = hour, minute
+	p.hasTime = true
+	p.ambiguousHour = ambiguous
+}
+
+// Подстановка найденного относительно текущего момента
+func (p *parser) resolve(input string, now time.Time) (Result, error) {
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	// «через 2 часа» - точный момент, время суток к нему не добавить
+	if p.hasShift && p.shift.duration > 0 {
+		if p.hasTime {
+			return Result{}, ErrUnrecognized
+		}
+		t := now.Add(p.shift.duration).Truncate(time.Minute)
+		return Result{Time: t, HasDate: true, HasTime: true}, nil
+	}
+
+	// Варианты времени суток: один или два («в 7» - 7:00 или 19:00)
+	var clocks [][2]int
+	if p.hasTime {
+		clocks = append(clocks, [2]int{p.hour, p.minute})
+		if p.ambiguousHour {
+			clocks = append(clocks, [2]int{p.hour + 12, p.minute})
+		}
+	}
+	at := func(date time.Time, clock [2]int) time.Time {
+		return time.Date(date.Year(), date.Month(), date.Day(), clock[0], clock[1], 0, 0, loc)
+	}
+
+	// Варианты даты
+	var dates []time.Time
+	switch {
+	case p.hasOffset:
+		dates = append(dates, today.AddDate(0, 0, p.dayOffset))
+
+	case p.hasShift:
+		dates = append(dates, today.AddDate(0, p.shift.months, p.shift.days))
+
+	case p.hasWeekday:
+		if p.nextWeek {
+			// День следующей календарной недели (неделя начинается с понедельника)
+			monday := today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7)
+			dates = append(dates, monday.AddDate(0, 0, (int(p.weekday)+6)%7))
+			break
+		}
+
+		days := (int(p.weekday) - int(today.Weekday()) + 7) % 7
+		if days > 0 {
+			dates = append(dates, today.AddDate(0, 0, days))
+			break
+		}
+		// Сегодня тот же день недели: сегодня или через неделю?
+		if len(clocks) == 1 && !at(today, clocks[0]).After(now) {
+			dates = append(dates, today.AddDate(0, 0, 7))
+		} else {
+			dates = append(dates, today, today.AddDate(0, 0, 7))
+		}
+
+	case p.hasCalendar:
+		candidates := [][2]int{{p.month, p.day}}
+		if p.swappable {
+			candidates = append(candidates, [2]int{p.day, p.month})
+		}
+		for _, c := range candidates {
+			date, ok := calendarDate(p.year, c[0], c[1], today)
+			if !ok {
+				return Result{}, ErrUnrecognized
+			}
+			dates = append(dates, date)
+		}
+	}
+
+	var options []Result
+	if len(dates) == 0 {
+		// Только время: сегодня, а если оно уже прошло - завтра
+		for _, clock := range clocks {
+			t := at(today, clock)
+			if !t.After(now) {
+				t = at(today.AddDate(0, 0, 1), clock)
+			}
+			options = append(options, Result{Time: t, HasTime: true})
+		}
+	} else if len(clocks) == 0 {
+		for _, date := range dates {
+			options = append(options, Result{Time: date, HasDate: true})
+		}
+	} else {
+		for _, date := range dates {
+			for _, clock := range clocks {
+				options = append(options, Result{Time: at(date, clock), HasDate: true, HasTime: true})
+			}
+		}
+	}
+
+	// Одни служебные слова: «в», «the», «on»
+	if len(options) == 0 {
+		return Result{}, ErrUnrecognized
+	}
+	if len(options) > 1 {
+		return Result{}, &AmbiguousError{Input: input, Options: options}
+	}
+	return options[0], nil
+}
+
+// Дата по числу и месяцу; без года - ближайшая, начиная с сегодняшней
+func calendarDate(year, month, day int, today time.Time) (time.Time, bool) {
+	y := year
+	if y == 0 {
+		y = today.Year()
+	}
+
+	date := time.Date(y, time.Month(month), day, 0, 0, 0, 0, today.Location())
+	if year == 0 && date.Before(today) {
+		date = time.Date(y+1, time.Month(month), day, 0, 0, 0, 0, today.Location())
+	}
+	// 31.02 time.Date превратит в март
+	if date.Day() != day {
+		return time.Time{}, false
+	}
+	return date, true
+}
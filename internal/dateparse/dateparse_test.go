@@ -0,0 +1,129 @@
+package dateparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Среда, 14 октября 2026, 10:00
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, loc)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		input   string
+		want    time.Time
+		hasDate bool
+		hasTime bool
+	}{
+		{"завтра в 19:00", at(10, 15, 19, 0), true, true},
+		{"Завтра, 19:00", at(10, 15, 19, 0), true, true},
+		{"в пятницу 18:30", at(10, 16, 18, 30), true, true},
+		{"в следующую пятницу", at(10, 23, 0, 0), true, false},
+		{"next monday 7pm", at(10, 19, 19, 0), true, true},
+		{"sat", at(10, 17, 0, 0), true, false},
+		{"в среду в 9:00", at(10, 21, 9, 0), true, true},
+		{"25 декабря", at(12, 25, 0, 0), true, false},
+		{"25 дек. в 20:00", at(12, 25, 20, 0), true, true},
+		{"december 25th 2027 at 9am", time.Date(2027, 12, 25, 9, 0, 0, 0, loc), true, true},
+		{"1 мая 2027 г.", time.Date(2027, 5, 1, 0, 0, 0, 0, loc), true, false},
+		{"через 2 часа", at(10, 14, 12, 0), true, true},
+		{"через полчаса", at(10, 14, 10, 30), true, true},
+		{"in an hour", at(10, 14, 11, 0), true, true},
+		{"через 3 дня в 12:00", at(10, 17, 12, 0), true, true},
+		{"in 2 weeks", at(10, 28, 0, 0), true, false},
+		{"послезавтра в 7 вечера", at(10, 16, 19, 0), true, true},
+		{"tomorrow noon", at(10, 15, 12, 0), true, true},
+		{"сегодня в 11 ночи", at(10, 14, 23, 0), true, true},
+		{"31.12.2026", at(12, 31, 0, 0), true, false},
+		{"2026-11-03", at(11, 3, 0, 0), true, false},
+		{"14.10", at(10, 14, 0, 0), true, false},
+		{"5.10", time.Date(2027, 10, 5, 0, 0, 0, 0, loc), true, false},
+		{"25/12", at(12, 25, 0, 0), true, false},
+		{"19:00", at(10, 14, 19, 0), false, true},
+		{"9:00", at(10, 15, 9, 0), false, true},
+		{"в 2 ночи", at(10, 15, 2, 0), false, true},
+		{"полдень", at(10, 14, 12, 0), false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, now, loc)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if !got.Time.Equal(tt.want) || got.HasDate != tt.hasDate || got.HasTime != tt.hasTime {
+				t.Errorf("Parse(%q) = %v (date %v, time %v), want %v (date %v, time %v)",
+					tt.input, got.Time, got.HasDate, got.HasTime, tt.want, tt.hasDate, tt.hasTime)
+			}
+		})
+	}
+}
+
+func TestParseAmbiguous(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, loc)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		input string
+		want  []time.Time
+	}{
+		{"в 7", []time.Time{at(10, 15, 7, 0), at(10, 14, 19, 0)}},
+		{"завтра в 8", []time.Time{at(10, 15, 8, 0), at(10, 15, 20, 0)}},
+		{"в среду", []time.Time{at(10, 14, 0, 0), at(10, 21, 0, 0)}},
+		{"03/04", []time.Time{time.Date(2027, 4, 3, 0, 0, 0, 0, loc), time.Date(2027, 3, 4, 0, 0, 0, 0, loc)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input, now, loc)
+
+			var ambiguous *AmbiguousError
+			if !errors.As(err, &ambiguous) {
+				t.Fatalf("Parse(%q) error = %v, want AmbiguousError", tt.input, err)
+			}
+			if len(ambiguous.Options) != len(tt.want) {
+				t.Fatalf("Parse(%q) options = %v, want %v", tt.input, ambiguous.Options, tt.want)
+			}
+			for i, option := range ambiguous.Options {
+				if !option.Time.Equal(tt.want[i]) {
+					t.Errorf("Parse(%q) option %d = %v, want %v", tt.input, i, option.Time, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseUnrecognized(t *testing.T) {
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+
+	for _, input := range []string{
+		"",
+		"когда-нибудь",
+		"31.02",
+		"25:00",
+		"13/13",
+		"завтра послезавтра",
+		"через 2 часа в 19:00",
+		"в 19:00 в 20:00",
+		"в",
+		"the",
+		"on",
+	} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := Parse(input, now, time.UTC); !errors.Is(err, ErrUnrecognized) {
+				t.Errorf("Parse(%q) error = %v, want ErrUnrecognized", input, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package i18n
+
+// Английский каталог
+var english = &Locale{
+	Lang:            "en",
+	Name:            "English",
+	plural:          englishPlural,
+	dateLayout:      "Jan 2, 2006",
+	shortDateLayout: "Jan 2",
+	clockLayout:     "3:04 PM",
+	weekdays:        []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	messages: map[string]string{
+		// Общие
+		"auth_error":          "Authorization error. Please try again later.",
+		"unknown_command":     "Unknown command. Send /help for the list of commands.",
+		"language_unknown":    "No such language. Available: ru, en",
+		"settings_save_error": "Could not save settings",
+		"language_set":        "Language: %s",
+		"render_error":        "Could not prepare the reply",
+		"button_expired":      "This button is outdated, request the list again",
+		"unknown_action":      "Unknown action",
+		"button_edit":         "✏️ Edit",
+		"button_delete":       "🗑 Delete",
+		"button_calendar":     "📅 Add to calendar",
+
+		// Администрирование
+		"not_admin":             "❌ You are not an administrator",
+		"admin_bad_page":        "Invalid page number. Usage: /admin_users 2",
+		"admin_users_error":     "❌ Could not load users",
+		"admin_stats_error":     "❌ Could not load statistics",
+		"admin_makeadmin_usage": "Specify the user's Telegram ID: /admin_makeadmin 123456789",
+		"user_search_error":     "❌ Could not look up the user",
+		"user_not_found":        "User not found. They need to message the bot at least once.",
+		"admin_already":         "The user is already an admin",
+		"admin_revoke_usage":    "Specify the user's Telegram ID: /admin_revoke 123456789",
+		"admin_delete_usage":    "Specify the event ID: /admin_delete_event 42",
+		"event_search_error":    "❌ Could not look up the event",
+		"event_not_found":       "Event not found",
+		"admin_delete_error":    "❌ Could not delete the event",
+		"admin_last":            "❌ Cannot revoke the last admin",
+		"admin_configured":      "❌ This admin is listed in ADMIN_TELEGRAM_ID; their rights can only be revoked in the configuration",
+		"admin_makeadmin_error": "❌ Could not grant admin rights",
+		"admin_revoke_error":    "❌ Could not revoke admin rights",
+
+		// Мероприятия
+		"event_get_error":         "Could not load the event",
+		"delete_forbidden":        "Only the creator or an admin can delete this event",
+		"group_delete_forbidden":  "Only the group administrators can delete this group event",
+		"button_delete_confirm":   "🗑 Yes, delete",
+		"button_dismiss":          "Cancel",
+		"delete_error":            "Could not delete the event",
+		"event_deleted":           "Event deleted",
+		"capacity_unlimited":      "unlimited",
+		"cancel_event_usage":      "Specify the event ID: /cancel_event 42",
+		"cancel_forbidden":        "Only the creator or an admin can cancel this event",
+		"group_cancel_forbidden":  "Only the group administrators can cancel this group event",
+		"event_already_cancelled": "The event is already cancelled",
+		"event_already_ended":     "The event has already ended",
+		"cancel_error":            "❌ Could not cancel the event",
+		"status_planned":          "🗓 Planned",
+		"status_ongoing":          "🟢 Happening now",
+		"status_ended":            "✔️ Ended",
+		"status_cancelled":        "🚫 Cancelled",
+
+		// Участие
+		"attendance_going":     "✅ Going",
+		"attendance_maybe":     "🤔 Maybe",
+		"attendance_not_going": "❌ Not going",
+		"attendance_waitlist":  "⏳ Waitlisted",
+		"event_cancelled":      "The event is cancelled",
+		"event_passed":         "The event is already over",
+		"series_passed":        "All occurrences of this event are already over",
+		"attendance_error":     "Could not save your response",
+		"rsvp_going":           "You are going to “%s”",
+		"rsvp_waitlist":        "“%s” is full, you are on the waitlist. We will let you know when a spot opens up",
+		"rsvp_maybe":           "Marked you as maybe for “%s”",
+		"rsvp_not_going":       "Marked you as not going to “%s”",
+		"join_usage":           "Specify the event ID: /join 42 or /join 42 maybe",
+		"join_bad_argument":    "The second argument can only be maybe",
+		"leave_usage":          "Specify the event ID: /leave 42",
+
+		// Часовой пояс
+		"tz_kaliningrad":          "Kaliningrad (UTC+2)",
+		"tz_moscow":               "Moscow (UTC+3)",
+		"tz_samara":               "Samara (UTC+4)",
+		"tz_yekaterinburg":        "Yekaterinburg (UTC+5)",
+		"tz_omsk":                 "Omsk (UTC+6)",
+		"tz_novosibirsk":          "Novosibirsk (UTC+7)",
+		"tz_irkutsk":              "Irkutsk (UTC+8)",
+		"tz_vladivostok":          "Vladivostok (UTC+10)",
+		"tz_utc":                  "UTC",
+		"timezone_unknown_button": "Unknown time zone",
+		"timezone_unknown":        "Unknown time zone. Example: Europe/London or UTC+3",
+		"timezone_set":            "Time zone: %s, it is %s now",
+		"all_day_date":            "%s, all day",
+		"all_day_dates":           "%s – %s, all day",
+
+		// Повторение
+		"repeat_invalid":          "Could not understand the repeat rule: %v",
+		"repeat_none":             "does not repeat",
+		"repeat_every_day":        "every day",
+		"repeat_every_week":       "every week",
+		"repeat_every_month":      "every month",
+		"repeat_every_n_day":      "every %d day|every %d days",
+		"repeat_every_n_week":     "every %d week|every %d weeks",
+		"repeat_every_n_month":    "every %d month|every %d months",
+		"repeat_last_weekday":     "last %s",
+		"repeat_nth_weekday":      "#%d %s",
+		"repeat_count":            "%d time|%d times",
+		"repeat_until":            "until %s",
+		"button_scope_occurrence": "Only %s",
+		"button_scope_series":     "Whole series",
+		"button_scope_skip":       "Skip %s",
+		"edit_forbidden":          "Only the creator or an admin can edit this event",
+		"group_edit_forbidden":    "Only the group administrators can edit this group event",
+		"occurrence_gone":         "This occurrence has already been skipped or changed",
+		"event_save_error":        "Could not save the event",
+		"occurrence_skipped":      "Occurrence skipped",
+
+		// Уведомления и напоминания
+		"change_recurrence": "repeat: %s → %s",
+		"change_time":       "time: %s → %s",
+		"change_title":      "title: %s → %s",
+		"change_date":       "date: %s → %s",
+		"change_location":   "place: %s → %s",
+		"offset_days":       "%d day|%d days",
+		"offset_hours":      "%d hour|%d hours",
+		"offset_minutes":    "%d minute|%d minutes",
+
+		// Диалоги
+		"dialog_expired":  "This dialog is outdated, please start over",
+		"done":            "Done",
+		"dialog_error":    "Something went wrong, the dialog was interrupted. Please try again.",
+		"cancel_nothing":  "Nothing to cancel",
+		"cancel_done":     "Cancelled",
+		"back_no_dialog":  "No active dialog",
+		"back_first_step": "This is the first step.",
+
+		// Создание мероприятия
+		"answer_unclear":        "Could not understand the answer.",
+		"create_start":          "Creating a new event. /cancel to cancel, /back to go one step back.",
+		"create_error":          "Could not create the event",
+		"all_day":               "all day",
+		"date_hint":             "Examples: “tomorrow”, “friday 6:30pm”, “December 25”, 31.12.2026.",
+		"date_required":         "Specify a date, for example “tomorrow 7pm” or 31.12.2026.",
+		"date_passed":           "This date has already passed.",
+		"time_end_equals_start": "The end time is the same as the start time.",
+		"time_passed":           "This time has already passed. Enter a time in the future or go back to the date with /back.",
+		"value_empty":           "The value cannot be empty.",
+		"value_too_long":        "Too long: %d character maximum.|Too long: %d characters maximum.",
+		"capacity_invalid":      "Enter a positive number or “-”.",
+		"capacity_too_many":     "Too many spots.",
+		"time_hint":             "Use HH:MM, for example 19:30.",
+		"time_only":             "Enter only the time, for example 19:30.",
+		"date_unclear":          "Could not understand the date or time. %s",
+		"quoted":                "“%s”",
+		"or":                    " or ",
+		"date_ambiguous":        "This can be read in several ways: %s. Please be more specific, for example add a date, “am” or “pm”.",
+		"time_invalid":          "Invalid time format. Use HH:MM.",
+
+		// Редактирование
+		"field_title":            "Title",
+		"field_description":      "Description",
+		"field_date":             "Date",
+		"field_time":             "Time",
+		"field_recurrence":       "Repeat",
+		"field_location":         "Place",
+		"field_capacity":         "Spots",
+		"field_unclear":          "Could not understand which field to change.",
+		"button_done":            "✔️ Done",
+		"edit_usage":             "Specify the event ID: /edit 42",
+		"field_unknown":          "Unknown field",
+		"recurrence_series_only": "Repeat can only be changed for the whole series",
+		"edit_time_passed":       "This time has already passed, the change was not saved.",
+		"edit_rule_detached":     "The repeat rule cannot be changed because some occurrences were detached from the series; the change was not saved. You can still move the whole series.",
+		"edit_saved":             "✅ Saved",
+		"edit_saved_detached":    "✅ Saved. This occurrence is now a separate event (ID %d)",
+
+		// Список мероприятий
+		"period_month":          "for the next 30 days",
+		"period_today":          "for today",
+		"period_tomorrow":       "for tomorrow",
+		"period_week":           "for the next 7 days",
+		"period_past":           "for the last 30 days",
+		"creator_me":            ", created by you",
+		"creator_required":      "Specify the creator: /events by @username or /events by 12345",
+		"period_day":            "for %s",
+		"period_range":          "from %s to %s",
+		"creator_id":            ", created by user %d",
+		"creator_search_error":  "Could not look up the user",
+		"creator_not_found":     "User @%s not found",
+		"creator_username":      ", created by @%s",
+		"range_reversed":        "The end of the range is before its start",
+		"range_too_long":        "The range cannot be longer than a year",
+		"events_filter_unclear": "Could not understand the filter. Examples: /events today, /events week, /events past, /events 01.12.2026-15.12.2026, /events mine, /events @username",
+		"events_error":          "Could not load events",
+		"events_list_expired":   "This list is outdated, request it again: /events",
+		"events_list_changed":   "The list has changed, request it again: /events",
+		"button_prev":           "◀️ Back",
+		"button_next":           "Next ▶️",
+
+		// Поиск
+		"search_usage":   "Specify what to search for: /search concert",
+		"search_error":   "Could not search events",
+		"search_expired": "This search is outdated, repeat /search",
+
+		// Импорт
+		"import_busy":                       "Finish or cancel the current action first: /cancel",
+		"import_download_error":             "Could not download the file. Please try again.",
+		"import_parse_error":                "Could not read the file: %s",
+		"import_empty":                      "There are no events in the file",
+		"import_check_error":                "Could not check the events",
+		"import_read_error":                 "Could not read the file",
+		"import_title_too_long":             "title is longer than %d character|title is longer than %d characters",
+		"import_description_too_long":       "description is longer than %d character|description is longer than %d characters",
+		"import_location_too_long":          "place is longer than %d character|place is longer than %d characters",
+		"import_capacity_too_many":          "too many spots",
+		"import_event_passed":               "the event is already over",
+		"import_reason_unsupported_format":  "only .ics and .csv files are supported",
+		"import_reason_unreadable":          "the file could not be read (%s)",
+		"import_reason_not_calendar":        "this is not an iCalendar file",
+		"import_reason_empty_file":          "the file is empty",
+		"import_reason_missing_column":      "the header has no %s column",
+		"import_reason_bad_line":            "invalid line %q",
+		"import_reason_modified_occurrence": "modified occurrences of a series are not supported",
+		"import_reason_no_title":            "no title",
+		"import_reason_no_summary":          "no title (SUMMARY)",
+		"import_reason_cancelled":           "the event is cancelled",
+		"import_reason_no_start":            "no start date (DTSTART)",
+		"import_reason_end_before_start":    "the end is before the start",
+		"import_reason_bad_timezone":        "unknown time zone %q",
+		"import_reason_bad_date":            "invalid date %q",
+		"import_reason_bad_time":            "invalid time %q",
+		"import_reason_bad_csv_date":        "invalid date %q, use YYYY-MM-DD or DD.MM.YYYY",
+		"import_reason_bad_csv_time":        "invalid time %q, use HH:MM",
+		"import_reason_bad_duration":        "invalid duration %q",
+		"import_reason_bad_capacity":        "invalid number of places %q",
+		"import_reason_bad_recurrence":      "invalid repeat rule: %s",
+		"import_too_many_rows":              "The file has %d event|The file has %d events",
+		"import_added":                      "%d event added|%d events added",
+		"import_error":                      "Could not import events",
+		"import_failed":                     "Could not import events, nothing was added",
+
+		// Календарь
+		"ics_usage":         "Specify the event ID: /ics 42",
+		"ics_event_error":   "Could not export the event",
+		"ics_event_caption": "Open the file to add the event to your calendar",
+		"ics_calendar_name": "Events",
+		"ics_events_error":  "Could not export events",
+		"ics_no_events":     "No upcoming events",
+		"ics_all_caption":   "Upcoming events: %d. Open the file to add them to your calendar",
+
+		// Настройки
+		"settings_error":            "Could not load settings",
+		"settings_saved":            "Saved",
+		"settings_default":          " (default)",
+		"reminders_off":             "off",
+		"reminders_before":          "%s before",
+		"digest_off":                "off",
+		"digest_daily":              "every morning",
+		"digest_weekly":             "once a week",
+		"privacy_everyone":          "visible to everyone",
+		"privacy_attendees":         "visible to those who also signed up",
+		"privacy_organizer":         "visible to the organizer only",
+		"attendee_hidden":           "🙈 name hidden",
+		"button_settings_timezone":  "🕒 Time zone",
+		"button_settings_language":  "🌐 Language",
+		"button_settings_reminders": "⏰ Reminders",
+		"button_settings_digest":    "📰 Digest",
+		"button_settings_privacy":   "🔒 Privacy",
+	},
+}
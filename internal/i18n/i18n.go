@@ -0,0 +1,129 @@
+// Package i18n - каталоги сообщений бота, правила множественного числа
+// и формат дат для каждого языка
+package i18n
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// Язык для пользователей, чей язык бот не знает
+const DefaultLanguage = "ru"
+
+// Язык интерфейса
+type Locale struct {
+	Lang string // код языка: ru, en
+	Name string // название языка на нём самом
+
+	messages map[string]string
+	// plural - номер формы слова для числа n в сообщениях с формами через «|»
+	plural func(n int) int
+
+	dateLayout      string
+	shortDateLayout string // дата без года
+	clockLayout     string
+	weekdays        []string // краткие названия дней недели, с воскресенья
+}
+
+var locales = map[string]*Locale{
+	russian.Lang: russian,
+	english.Lang: english,
+}
+
+// Поддерживаемые языки в порядке показа
+var Languages = []string{russian.Lang, english.Lang}
+
+// Язык по коду; неизвестный код - язык по умолчанию
+func Get(lang string) *Locale {
+	if l, ok := locales[lang]; ok {
+		return l
+	}
+	return locales[DefaultLanguage]
+}
+
+// Поддерживаемый язык по коду из Telegram («en-US», «ru»);
+// false, если такого языка нет
+func Match(code string) (string, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	_, ok := locales[code]
+	return code, ok
+}
+
+// Сообщение по ключу; args подставляются как в fmt.Sprintf.
+// Если в каталоге языка ключа нет, берётся язык по умолчанию
+func (l *Locale) T(key string, args ...interface{}) string {
+	msg, ok := l.messages[key]
+	if !ok {
+		if msg, ok = locales[DefaultLanguage].messages[key]; !ok {
+			log.Printf("Нет сообщения %q", key)
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Сообщение с числом: форма выбирается по n, n подставляется первым аргументом
+func (l *Locale) N(key string, n int, args ...interface{}) string {
+	forms := strings.Split(l.T(key), "|")
+	form := forms[len(forms)-1]
+	if i := l.plural(n); i < len(forms) {
+		form = forms[i]
+	}
+	return fmt.Sprintf(form, append([]interface{}{n}, args...)...)
+}
+
+// Дата: «31.12.2026», «Dec 31, 2026»
+func (l *Locale) Date(t time.Time) string {
+	return t.Format(l.dateLayout)
+}
+
+// Дата без года: «31.12», «Dec 31»
+func (l *Locale) ShortDate(t time.Time) string {
+	return t.Format(l.shortDateLayout)
+}
+
+// Время суток: «19:30», «7:30 PM»
+func (l *Locale) Clock(t time.Time) string {
+	return t.Format(l.clockLayout)
+}
+
+// Дата и время
+func (l *Locale) DateTime(t time.Time) string {
+	return l.Date(t) + " " + l.Clock(t)
+}
+
+// Краткое название дня недели
+func (l *Locale) Weekday(d time.Weekday) string {
+	return l.weekdays[d]
+}
+
+// Правило для русского: 1 мероприятие, 2 мероприятия, 5 мероприятий
+func russianPlural(n int) int {
+	if n < 0 {
+		n = -n
+	}
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return 0
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Правило для английского: 1 event, 2 events
+func englishPlural(n int) int {
+	if n == 1 || n == -1 {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,272 @@
+package i18n
+
+// Русский каталог, он же язык по умолчанию
+var russian = &Locale{
+	Lang:            "ru",
+	Name:            "Русский",
+	plural:          russianPlural,
+	dateLayout:      "02.01.2006",
+	shortDateLayout: "02.01",
+	clockLayout:     "15:04",
+	weekdays:        []string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+	messages: map[string]string{
+		// Общие
+		"auth_error":          "Ошибка авторизации. Попробуйте позже.",
+		"unknown_command":     "Неизвестная команда. Напишите /help для списка команд.",
+		"language_unknown":    "Такого языка нет. Доступны: ru, en",
+		"settings_save_error": "Ошибка при сохранении настроек",
+		"language_set":        "Язык: %s",
+		"render_error":        "Ошибка при подготовке ответа",
+		"button_expired":      "Кнопка устарела, запросите список заново",
+		"unknown_action":      "Неизвестное действие",
+		"button_edit":         "✏️ Изменить",
+		"button_delete":       "🗑 Удалить",
+		"button_calendar":     "📅 В календарь",
+
+		// Администрирование
+		"not_admin":             "❌ У вас нет прав администратора",
+		"admin_bad_page":        "Неверный номер страницы. Используйте: /admin_users 2",
+		"admin_users_error":     "❌ Ошибка при получении пользователей",
+		"admin_stats_error":     "❌ Ошибка при получении статистики",
+		"admin_makeadmin_usage": "Укажите Telegram ID пользователя: /admin_makeadmin 123456789",
+		"user_search_error":     "❌ Ошибка при поиске пользователя",
+		"user_not_found":        "Пользователь не найден. Он должен хотя бы раз написать боту.",
+		"admin_already":         "Пользователь уже является админом",
+		"admin_revoke_usage":    "Укажите Telegram ID пользователя: /admin_revoke 123456789",
+		"admin_delete_usage":    "Укажите ID мероприятия: /admin_delete_event 42",
+		"event_search_error":    "❌ Ошибка при поиске мероприятия",
+		"event_not_found":       "Мероприятие не найдено",
+		"admin_delete_error":    "❌ Ошибка при удалении мероприятия",
+		"admin_last":            "❌ Нельзя снять права с последнего админа",
+		"admin_configured":      "❌ Этот админ указан в ADMIN_TELEGRAM_ID, права снимаются только в конфигурации",
+		"admin_makeadmin_error": "❌ Не удалось назначить админа",
+		"admin_revoke_error":    "❌ Не удалось снять права админа",
+
+		// Мероприятия
+		"event_get_error":         "Ошибка при получении мероприятия",
+		"delete_forbidden":        "Удалить мероприятие может только создатель или админ",
+		"group_delete_forbidden":  "Удалить мероприятие группы могут только администраторы группы",
+		"button_delete_confirm":   "🗑 Да, удалить",
+		"button_dismiss":          "Отмена",
+		"delete_error":            "Ошибка при удалении мероприятия",
+		"event_deleted":           "Мероприятие удалено",
+		"capacity_unlimited":      "без ограничений",
+		"cancel_event_usage":      "Укажите ID мероприятия: /cancel_event 42",
+		"cancel_forbidden":        "Отменить мероприятие может только создатель или админ",
+		"group_cancel_forbidden":  "Отменить мероприятие группы могут только администраторы группы",
+		"event_already_cancelled": "Мероприятие уже отменено",
+		"event_already_ended":     "Мероприятие уже завершилось",
+		"cancel_error":            "❌ Ошибка при отмене мероприятия",
+		"status_planned":          "🗓 Запланировано",
+		"status_ongoing":          "🟢 Идёт сейчас",
+		"status_ended":            "✔️ Завершено",
+		"status_cancelled":        "🚫 Отменено",
+
+		// Участие
+		"attendance_going":     "✅ Иду",
+		"attendance_maybe":     "🤔 Возможно",
+		"attendance_not_going": "❌ Не иду",
+		"attendance_waitlist":  "⏳ В очереди",
+		"event_cancelled":      "Мероприятие отменено",
+		"event_passed":         "Мероприятие уже прошло",
+		"series_passed":        "Все повторения мероприятия уже прошли",
+		"attendance_error":     "Ошибка при записи на мероприятие",
+		"rsvp_going":           "Вы записаны на «%s»",
+		"rsvp_waitlist":        "Мест на «%s» нет, вы в очереди. Мы напишем, когда место освободится",
+		"rsvp_maybe":           "Отметили, что вы, возможно, придёте на «%s»",
+		"rsvp_not_going":       "Отметили, что вы не придёте на «%s»",
+		"join_usage":           "Укажите ID мероприятия: /join 42 или /join 42 maybe",
+		"join_bad_argument":    "Второй аргумент может быть только maybe",
+		"leave_usage":          "Укажите ID мероприятия: /leave 42",
+
+		// Часовой пояс
+		"tz_kaliningrad":          "Калининград (UTC+2)",
+		"tz_moscow":               "Москва (UTC+3)",
+		"tz_samara":               "Самара (UTC+4)",
+		"tz_yekaterinburg":        "Екатеринбург (UTC+5)",
+		"tz_omsk":                 "Омск (UTC+6)",
+		"tz_novosibirsk":          "Новосибирск (UTC+7)",
+		"tz_irkutsk":              "Иркутск (UTC+8)",
+		"tz_vladivostok":          "Владивосток (UTC+10)",
+		"tz_utc":                  "UTC",
+		"timezone_unknown_button": "Неизвестный часовой пояс",
+		"timezone_unknown":        "Не знаю такой часовой пояс. Пример: Europe/Moscow или UTC+3",
+		"timezone_set":            "Часовой пояс: %s, сейчас %s",
+		"all_day_date":            "%s, весь день",
+		"all_day_dates":           "%s – %s, весь день",
+
+		// Повторение
+		"repeat_invalid":          "Не понял правило повторения: %v",
+		"repeat_none":             "не повторяется",
+		"repeat_every_day":        "каждый день",
+		"repeat_every_week":       "каждую неделю",
+		"repeat_every_month":      "каждый месяц",
+		"repeat_every_n_day":      "раз в %d день|раз в %d дня|раз в %d дней",
+		"repeat_every_n_week":     "раз в %d неделю|раз в %d недели|раз в %d недель",
+		"repeat_every_n_month":    "раз в %d месяц|раз в %d месяца|раз в %d месяцев",
+		"repeat_last_weekday":     "последний %s",
+		"repeat_nth_weekday":      "%d-й %s",
+		"repeat_count":            "%d раз|%d раза|%d раз",
+		"repeat_until":            "до %s",
+		"button_scope_occurrence": "Только %s",
+		"button_scope_series":     "Всю серию",
+		"button_scope_skip":       "Пропустить %s",
+		"edit_forbidden":          "Изменить мероприятие может только создатель или админ",
+		"group_edit_forbidden":    "Изменить мероприятие группы могут только администраторы группы",
+		"occurrence_gone":         "Это повторение уже пропущено или изменено",
+		"event_save_error":        "Ошибка при сохранении мероприятия",
+		"occurrence_skipped":      "Повторение пропущено",
+
+		// Уведомления и напоминания
+		"change_recurrence": "повтор: %s → %s",
+		"change_time":       "время: %s → %s",
+		"change_title":      "название: %s → %s",
+		"change_date":       "дата: %s → %s",
+		"change_location":   "место: %s → %s",
+		"offset_days":       "%d день|%d дня|%d дней",
+		"offset_hours":      "%d час|%d часа|%d часов",
+		"offset_minutes":    "%d минуту|%d минуты|%d минут",
+
+		// Диалоги
+		"dialog_expired":  "Диалог устарел, начните заново",
+		"done":            "Готово",
+		"dialog_error":    "Ошибка, диалог прерван. Попробуйте ещё раз.",
+		"cancel_nothing":  "Нечего отменять",
+		"cancel_done":     "Действие отменено",
+		"back_no_dialog":  "Нет активного диалога",
+		"back_first_step": "Это первый шаг.",
+
+		// Создание мероприятия
+		"answer_unclear":        "Не понял ответ.",
+		"create_start":          "Создаём новое мероприятие. /cancel — отменить, /back — вернуться на шаг назад.",
+		"create_error":          "Ошибка при создании мероприятия",
+		"all_day":               "весь день",
+		"date_hint":             "Примеры: «завтра», «в пятницу 18:30», «25 декабря», 31.12.2026.",
+		"date_required":         "Укажите дату, например: «завтра в 19:00» или 31.12.2026.",
+		"date_passed":           "Эта дата уже прошла.",
+		"time_end_equals_start": "Время окончания совпадает с началом.",
+		"time_passed":           "Это время уже прошло. Укажите время в будущем или вернитесь к дате через /back.",
+		"value_empty":           "Значение не может быть пустым.",
+		"value_too_long":        "Слишком длинно: максимум %d символ.|Слишком длинно: максимум %d символа.|Слишком длинно: максимум %d символов.",
+		"capacity_invalid":      "Введите положительное число или «-».",
+		"capacity_too_many":     "Слишком много мест.",
+		"time_hint":             "Используйте ЧЧ:ММ, например 19:30.",
+		"time_only":             "Введите только время, например 19:30.",
+		"date_unclear":          "Не понял дату или время. %s",
+		"quoted":                "«%s»",
+		"or":                    " или ",
+		"date_ambiguous":        "Можно понять по-разному: %s. Уточните, например, добавьте дату, «утра» или «вечера».",
+		"time_invalid":          "Неверный формат времени. Используйте ЧЧ:ММ.",
+
+		// Редактирование
+		"field_title":            "Название",
+		"field_description":      "Описание",
+		"field_date":             "Дата",
+		"field_time":             "Время",
+		"field_recurrence":       "Повтор",
+		"field_location":         "Место",
+		"field_capacity":         "Количество мест",
+		"field_unclear":          "Не понял, какое поле изменить.",
+		"button_done":            "✔️ Готово",
+		"edit_usage":             "Укажите ID мероприятия: /edit 42",
+		"field_unknown":          "Неизвестное поле",
+		"recurrence_series_only": "Повтор меняется только у всей серии",
+		"edit_time_passed":       "Это время уже прошло, изменение не сохранено.",
+		"edit_rule_detached":     "Правило повторения нельзя изменить: от серии отделены повторения, изменение не сохранено. Перенести серию целиком можно.",
+		"edit_saved":             "✅ Сохранено",
+		"edit_saved_detached":    "✅ Сохранено. Это повторение теперь отдельное мероприятие (ID %d)",
+
+		// Список мероприятий
+		"period_month":          "на ближайшие 30 дней",
+		"period_today":          "на сегодня",
+		"period_tomorrow":       "на завтра",
+		"period_week":           "на ближайшие 7 дней",
+		"period_past":           "за последние 30 дней",
+		"creator_me":            ", созданные вами",
+		"creator_required":      "Укажите создателя: /events by @username или /events by 12345",
+		"period_day":            "на %s",
+		"period_range":          "с %s по %s",
+		"creator_id":            ", созданные пользователем %d",
+		"creator_search_error":  "Ошибка при поиске пользователя",
+		"creator_not_found":     "Пользователь @%s не найден",
+		"creator_username":      ", созданные @%s",
+		"range_reversed":        "Конец промежутка раньше начала",
+		"range_too_long":        "Промежуток не может быть длиннее года",
+		"events_filter_unclear": "Не понял фильтр. Примеры: /events today, /events week, /events past, /events 01.12.2026-15.12.2026, /events mine, /events @username",
+		"events_error":          "Ошибка при получении мероприятий",
+		"events_list_expired":   "Список устарел, запросите его заново: /events",
+		"events_list_changed":   "Список изменился, запросите его заново: /events",
+		"button_prev":           "◀️ Назад",
+		"button_next":           "Дальше ▶️",
+
+		// Поиск
+		"search_usage":   "Укажите, что искать: /search концерт",
+		"search_error":   "Ошибка при поиске мероприятий",
+		"search_expired": "Поиск устарел, повторите /search",
+
+		// Импорт
+		"import_busy":                       "Сначала закончите текущее действие или отмените его: /cancel",
+		"import_download_error":             "Не удалось скачать файл. Попробуйте ещё раз.",
+		"import_parse_error":                "Не удалось прочитать файл: %s",
+		"import_empty":                      "В файле нет мероприятий",
+		"import_check_error":                "Ошибка при проверке мероприятий",
+		"import_read_error":                 "Ошибка при чтении файла",
+		"import_title_too_long":             "название длиннее %d символа|название длиннее %d символов|название длиннее %d символов",
+		"import_description_too_long":       "описание длиннее %d символа|описание длиннее %d символов|описание длиннее %d символов",
+		"import_location_too_long":          "место длиннее %d символа|место длиннее %d символов|место длиннее %d символов",
+		"import_capacity_too_many":          "слишком много мест",
+		"import_event_passed":               "мероприятие уже прошло",
+		"import_reason_unsupported_format":  "поддерживаются только файлы .ics и .csv",
+		"import_reason_unreadable":          "не удалось прочитать файл (%s)",
+		"import_reason_not_calendar":        "это не файл iCalendar",
+		"import_reason_empty_file":          "файл пустой",
+		"import_reason_missing_column":      "в заголовке нет колонки %s",
+		"import_reason_bad_line":            "неверная строка %q",
+		"import_reason_modified_occurrence": "изменённое повторение серии не поддерживается",
+		"import_reason_no_title":            "нет названия",
+		"import_reason_no_summary":          "нет названия (SUMMARY)",
+		"import_reason_cancelled":           "мероприятие отменено",
+		"import_reason_no_start":            "нет даты начала (DTSTART)",
+		"import_reason_end_before_start":    "окончание раньше начала",
+		"import_reason_bad_timezone":        "неизвестный часовой пояс %q",
+		"import_reason_bad_date":            "неверная дата %q",
+		"import_reason_bad_time":            "неверное время %q",
+		"import_reason_bad_csv_date":        "неверная дата %q, нужна ГГГГ-ММ-ДД или ДД.ММ.ГГГГ",
+		"import_reason_bad_csv_time":        "неверное время %q, нужно ЧЧ:ММ",
+		"import_reason_bad_duration":        "неверная длительность %q",
+		"import_reason_bad_capacity":        "неверное количество мест %q",
+		"import_reason_bad_recurrence":      "неверное правило повторения: %s",
+		"import_too_many_rows":              "В файле %d мероприятие|В файле %d мероприятия|В файле %d мероприятий",
+		"import_added":                      "Добавлено %d мероприятие|Добавлено %d мероприятия|Добавлено %d мероприятий",
+		"import_error":                      "Ошибка при импорте мероприятий",
+		"import_failed":                     "Ошибка при импорте мероприятий, ничего не добавлено",
+
+		// Календарь
+		"ics_usage":         "Укажите ID мероприятия: /ics 42",
+		"ics_event_error":   "Ошибка при выгрузке мероприятия",
+		"ics_event_caption": "Откройте файл, чтобы добавить мероприятие в календарь",
+		"ics_calendar_name": "Мероприятия",
+		"ics_events_error":  "Ошибка при выгрузке мероприятий",
+		"ics_no_events":     "Предстоящих мероприятий нет",
+		"ics_all_caption":   "Предстоящие мероприятия: %d. Откройте файл, чтобы добавить их в календарь",
+
+		// Настройки
+		"settings_error":            "Ошибка при получении настроек",
+		"settings_saved":            "Сохранено",
+		"settings_default":          " (по умолчанию)",
+		"reminders_off":             "не напоминать",
+		"reminders_before":          "за %s",
+		"digest_off":                "не присылать",
+		"digest_daily":              "каждое утро",
+		"digest_weekly":             "раз в неделю",
+		"privacy_everyone":          "видно всем",
+		"privacy_attendees":         "видно тем, кто тоже записался",
+		"privacy_organizer":         "видно только организатору",
+		"attendee_hidden":           "🙈 имя скрыто",
+		"button_settings_timezone":  "🕒 Часовой пояс",
+		"button_settings_language":  "🌐 Язык",
+		"button_settings_reminders": "⏰ Напоминания",
+		"button_settings_digest":    "📰 Сводка",
+		"button_settings_privacy":   "🔒 Приватность",
+	},
+}
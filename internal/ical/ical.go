@@ -0,0 +1,347 @@
+// Выгрузка мероприятий в формате iCalendar (RFC 5545)
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"event-planner-bot/internal/models"
+	"event-planner-bot/internal/recurrence"
+)
+
+const (
+	productID = "-//event-planner-bot//RU"
+	uidDomain = "event-planner-bot"
+
+	// Строки длиннее 75 байт переносятся (RFC 5545, 3.1)
+	maxLineOctets = 75
+
+	// Переходы на летнее время в VTIMEZONE описываются с запасом в год
+	// вокруг мероприятий, а для серий - ещё на несколько лет вперёд
+	timezoneMarginYears = 1
+	timezoneSeriesYears = 5
+)
+
+// Календарь из нескольких мероприятий
+type Calendar struct {
+	name   string
+	stamp  time.Time
+	events []calendarEvent
+}
+
+type calendarEvent struct {
+	event      *models.Event
+	exceptions []time.Time
+}
+
+// Новый календарь; name - название для календарных приложений,
+// now - момент выгрузки (DTSTAMP)
+func NewCalendar(name string, now time.Time) *Calendar {
+	return &Calendar{name: name, stamp: now.UTC()}
+}
+
+// Добавление мероприятия; exceptions - пропущенные повторения серии (EXDATE)
+func (c *Calendar) Add(event *models.Event, exceptions []time.Time) {
+	c.events = append(c.events, calendarEvent{event: event, exceptions: exceptions})
+}
+
+// Календарь в виде .ics-документа
+func (c *Calendar) Bytes() []byte {
+	var buf bytes.Buffer
+	c.WriteTo(&buf)
+	return buf.Bytes()
+}
+
+// Запись календаря в w
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	cw := &contentWriter{w: w}
+
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:" + productID)
+	cw.line("CALSCALE:GREGORIAN")
+	cw.line("METHOD:PUBLISH")
+	if c.name != "" {
+		cw.line("X-WR-CALNAME:" + escapeText(c.name))
+	}
+
+	for _, tz := range c.timezones() {
+		writeTimezone(cw, tz.loc, tz.from, tz.to)
+	}
+	for _, e := range c.events {
+		c.writeEvent(cw, e)
+	}
+
+	cw.line("END:VCALENDAR")
+	return cw.n, cw.err
+}
+
+// Часовые пояса мероприятий и годы, которые нужно описать в VTIMEZONE
+type timezoneRange struct {
+	loc      *time.Location
+	from, to time.Time
+}
+
+func (c *Calendar) timezones() []timezoneRange {
+	byName := make(map[string]*timezoneRange)
+	for _, e := range c.events {
+		loc := eventLocation(e.event)
+		if loc == time.UTC {
+			continue
+		}
+
+		from, to := e.event.EventDate, e.event.EndTime()
+		if e.event.IsRecurring() {
+			to = to.AddDate(timezoneSeriesYears, 0, 0)
+		}
+
+		tz, ok := byName[loc.String()]
+		if !ok {
+			byName[loc.String()] = &timezoneRange{loc: loc, from: from, to: to}
+			continue
+		}
+		if from.Before(tz.from) {
+			tz.from = from
+		}
+		if to.After(tz.to) {
+			tz.to = to
+		}
+	}
+
+	result := make([]timezoneRange, 0, len(byName))
+	for _, tz := range byName {
+		result = append(result, *tz)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].loc.String() < result[j].loc.String() })
+	return result
+}
+
+// Часовой пояс, в котором выгружается мероприятие; без пояса - UTC
+func eventLocation(event *models.Event) *time.Location {
+	return event.TimeLocation(time.UTC)
+}
+
+func (c *Calendar) writeEvent(cw *contentWriter, e calendarEvent) {
+	event := e.event
+	loc := eventLocation(event)
+
+	cw.line("BEGIN:VEVENT")
+	cw.line(fmt.Sprintf("UID:event-%d@%s", event.ID, uidDomain))
+	cw.line("DTSTAMP:" + formatUTC(c.stamp))
+	if !event.CreatedAt.IsZero() {
+		cw.line("CREATED:" + formatUTC(event.CreatedAt))
+	}
+	if !event.UpdatedAt.IsZero() {
+		cw.line("LAST-MODIFIED:" + formatUTC(event.UpdatedAt))
+	}
+
+	if event.AllDay {
+		cw.line("DTSTART;VALUE=DATE:" + event.EventDate.In(loc).Format("20060102"))
+		cw.line("DTEND;VALUE=DATE:" + event.EndTime().In(loc).Format("20060102"))
+	} else {
+		cw.line("DTSTART" + formatDateTime(event.EventDate, loc))
+		cw.line("DTEND" + formatDateTime(event.EndTime(), loc))
+	}
+
+	if event.IsRecurring() {
+		cw.line("RRULE:" + formatRule(event, loc))
+		for _, t := range e.exceptions {
+			if event.AllDay {
+				cw.line("EXDATE;VALUE=DATE:" + t.In(loc).Format("20060102"))
+			} else {
+				cw.line("EXDATE" + formatDateTime(t, loc))
+			}
+		}
+	}
+
+	cw.line("SUMMARY:" + escapeText(event.Title))
+	if event.Description != "" {
+		cw.line("DESCRIPTION:" + escapeText(event.Description))
+	}
+	if event.Location != "" {
+		cw.line("LOCATION:" + escapeText(event.Location))
+	}
+
+	if event.Status == models.StatusCancelled {
+		cw.line("STATUS:CANCELLED")
+	} else {
+		cw.line("STATUS:CONFIRMED")
+	}
+	cw.line("END:VEVENT")
+}
+
+// RRULE мероприятия. У мероприятия на весь день DTSTART - дата,
+// и UNTIL тоже должен быть датой
+func formatRule(event *models.Event, loc *time.Location) string {
+	rule, err := recurrence.Parse(event.Recurrence)
+	if err != nil {
+		return event.Recurrence
+	}
+	if !event.AllDay || rule.Until.IsZero() {
+		return rule.String()
+	}
+
+	until := rule.Until
+	rule.Until = time.Time{}
+	return rule.String() + ";UNTIL=" + until.In(loc).Format("20060102")
+}
+
+// Значение даты-времени с параметром: ";TZID=Europe/Moscow:20261014T190000"
+// или ":20261014T160000Z" для UTC
+func formatDateTime(t time.Time, loc *time.Location) string {
+	if loc == time.UTC {
+		return ":" + formatUTC(t)
+	}
+	return ";TZID=" + quoteParam(loc.String()) + ":" + t.In(loc).Format("20060102T150405")
+}
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// Значение параметра в кавычках, если в нём есть : ; или ,
+func quoteParam(value string) string {
+	if strings.ContainsAny(value, ":;,") {
+		return `"` + strings.ReplaceAll(value, `"`, "") + `"`
+	}
+	return value
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// Экранирование значения типа TEXT (RFC 5545, 3.3.11)
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// VTIMEZONE с переходами между from и to (с запасом по годам).
+// Первое наблюдение задаёт смещение, действовавшее до первого перехода
+func writeTimezone(cw *contentWriter, loc *time.Location, from, to time.Time) {
+	from = from.AddDate(-timezoneMarginYears, 0, 0)
+	to = to.AddDate(timezoneMarginYears, 0, 0)
+
+	cw.line("BEGIN:VTIMEZONE")
+	cw.line("TZID:" + loc.String())
+
+	initial := from.In(loc)
+	name, prevOffset := initial.Zone()
+	kind := "STANDARD"
+	if initial.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	writeObservance(cw, kind, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), prevOffset, prevOffset, name)
+
+	for _, t := range zoneTransitions(loc, from, to) {
+		local := t.In(loc)
+		name, offset := local.Zone()
+
+		kind := "STANDARD"
+		if local.IsDST() {
+			kind = "DAYLIGHT"
+		}
+		// DTSTART наблюдения - местное время перехода по старому смещению
+		onset := t.Add(time.Duration(prevOffset) * time.Second).UTC()
+		writeObservance(cw, kind, onset, prevOffset, offset, name)
+		prevOffset = offset
+	}
+
+	cw.line("END:VTIMEZONE")
+}
+
+func writeObservance(cw *contentWriter, kind string, onset time.Time, offsetFrom, offsetTo int, name string) {
+	cw.line("BEGIN:" + kind)
+	cw.line("DTSTART:" + onset.Format("20060102T150405"))
+	cw.line("TZOFFSETFROM:" + formatOffset(offsetFrom))
+	cw.line("TZOFFSETTO:" + formatOffset(offsetTo))
+	if name != "" {
+		cw.line("TZNAME:" + escapeText(name))
+	}
+	cw.line("END:" + kind)
+}
+
+// Смещение от UTC в формате +0300
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// Моменты смены смещения пояса в промежутке [from, to]. Ищутся перебором
+// по суткам с уточнением двоичным поиском до секунды
+func zoneTransitions(loc *time.Location, from, to time.Time) []time.Time {
+	offsetAt := func(t time.Time) int {
+		_, offset := t.In(loc).Zone()
+		return offset
+	}
+
+	var transitions []time.Time
+	prev := from
+	for t := from.Add(24 * time.Hour); !prev.After(to); t = t.Add(24 * time.Hour) {
+		if offsetAt(t) != offsetAt(prev) {
+			lo, hi := prev, t
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2)
+				if offsetAt(mid) == offsetAt(lo) {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			transitions = append(transitions, hi.Truncate(time.Second))
+		}
+		prev = t
+	}
+	return transitions
+}
+
+// Запись строк содержимого с CRLF и переносом длинных строк
+type contentWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (cw *contentWriter) line(s string) {
+	if cw.err != nil {
+		return
+	}
+	n, err := io.WriteString(cw.w, foldLine(s))
+	cw.n += int64(n)
+	cw.err = err
+}
+
+// Перенос строки длиннее 75 байт: продолжение начинается с пробела.
+// Многобайтовые символы UTF-8 не разрываются
+func foldLine(s string) string {
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// Пробел в начале продолжения тоже занимает байт
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+	return b.String()
+}
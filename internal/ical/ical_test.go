@@ -0,0 +1,313 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"event-planner-bot/internal/models"
+	"event-planner-bot/internal/recurrence"
+)
+
+func TestRoundTrip(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(loc *time.Location, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, loc).UTC()
+	}
+
+	tests := []struct {
+		name       string
+		event      models.Event
+		exceptions []time.Time
+	}{
+		{
+			name: "cyrillic text with special characters",
+			event: models.Event{
+				ID:          1,
+				Title:       "Встреча; обсуждение, планы",
+				Description: "Повестка:\nпервое, второе; третье\nзахватите ноутбук \\ зарядку",
+				Location:    "Москва, ул. Тверская, д. 1",
+				EventDate:   at(moscow, 10, 14, 19, 0),
+				EndDate:     at(moscow, 10, 14, 21, 30),
+				Timezone:    "Europe/Moscow",
+			},
+		},
+		{
+			name: "long description",
+			event: models.Event{
+				ID:          2,
+				Title:       "Длинное описание",
+				Description: strings.TrimSpace(strings.Repeat("Очень подробное описание мероприятия 🎉 со смайликами. ", 20)),
+				EventDate:   at(time.UTC, 11, 2, 8, 0),
+				EndDate:     at(time.UTC, 11, 2, 9, 0),
+				Timezone:    "UTC",
+			},
+		},
+		{
+			name: "all day across several days",
+			event: models.Event{
+				ID:        3,
+				Title:     "Конференция",
+				EventDate: time.Date(2026, 10, 24, 0, 0, 0, 0, berlin).UTC(),
+				EndDate:   time.Date(2026, 10, 27, 0, 0, 0, 0, berlin).UTC(),
+				AllDay:    true,
+				Timezone:  "Europe/Berlin",
+			},
+		},
+		{
+			name: "weekly series across DST with skipped occurrences",
+			event: models.Event{
+				ID:         4,
+				Title:      "Йога",
+				Location:   "Зал №2",
+				EventDate:  at(berlin, 10, 19, 19, 0),
+				EndDate:    at(berlin, 10, 19, 20, 0),
+				Recurrence: "FREQ=WEEKLY;BYDAY=MO,TH;UNTIL=20261231T230000Z",
+				Timezone:   "Europe/Berlin",
+			},
+			exceptions: []time.Time{at(berlin, 10, 22, 19, 0), at(berlin, 10, 26, 19, 0)},
+		},
+		{
+			name: "all day monthly series",
+			event: models.Event{
+				ID:         5,
+				Title:      "День рождения клуба",
+				EventDate:  time.Date(2026, 3, 28, 0, 0, 0, 0, berlin).UTC(),
+				EndDate:    time.Date(2026, 3, 29, 0, 0, 0, 0, berlin).UTC(),
+				AllDay:     true,
+				Recurrence: "FREQ=MONTHLY;BYDAY=-1SA;COUNT=6",
+				Timezone:   "Europe/Berlin",
+			},
+			exceptions: []time.Time{time.Date(2026, 4, 25, 0, 0, 0, 0, berlin).UTC()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := NewCalendar("Мероприятия", at(time.UTC, 10, 1, 12, 0))
+			cal.Add(&tt.event, tt.exceptions)
+
+			// Даты на весь день выгружаются без пояса: читаем их в поясе мероприятия
+			items, err := Parse(bytes.NewReader(cal.Bytes()), tt.event.TimeLocation(time.UTC))
+			if err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+			if len(items) != 1 {
+				t.Fatalf("Parse returned %d items, want 1", len(items))
+			}
+			if items[0].Err != nil {
+				t.Fatalf("item error: %v", items[0].Err)
+			}
+
+			got, want := items[0].Event.Event, tt.event
+			if got.Title != want.Title || got.Description != want.Description || got.Location != want.Location {
+				t.Errorf("text = %q / %q / %q, want %q / %q / %q",
+					got.Title, got.Description, got.Location, want.Title, want.Description, want.Location)
+			}
+			if !got.EventDate.Equal(want.EventDate) || !got.EndDate.Equal(want.EndDate) || got.AllDay != want.AllDay {
+				t.Errorf("time = %v–%v (all day %v), want %v–%v (all day %v)",
+					got.EventDate, got.EndDate, got.AllDay, want.EventDate, want.EndDate, want.AllDay)
+			}
+			if got.Timezone != want.Timezone {
+				t.Errorf("timezone = %q, want %q", got.Timezone, want.Timezone)
+			}
+
+			wantRule := ""
+			if want.Recurrence != "" {
+				rule, err := recurrence.Parse(want.Recurrence)
+				if err != nil {
+					t.Fatal(err)
+				}
+				wantRule = rule.String()
+			}
+			if got.Recurrence != wantRule {
+				t.Errorf("recurrence = %q, want %q", got.Recurrence, wantRule)
+			}
+
+			exceptions := items[0].Event.Exceptions
+			if len(exceptions) != len(tt.exceptions) {
+				t.Fatalf("exceptions = %v, want %v", exceptions, tt.exceptions)
+			}
+			for i := range exceptions {
+				if !exceptions[i].Equal(tt.exceptions[i]) {
+					t.Errorf("exception %d = %v, want %v", i, exceptions[i], tt.exceptions[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFoldLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"short", "SUMMARY:Встреча"},
+		{"exactly 75 octets", "DESCRIPTION:" + strings.Repeat("a", 63)},
+		{"ascii", "DESCRIPTION:" + strings.Repeat("abcdefghij", 30)},
+		{"cyrillic", "DESCRIPTION:" + strings.Repeat("Привет, мир! ", 30)},
+		{"emoji", "SUMMARY:" + strings.Repeat("🎉", 50)},
+		{"mixed offsets", "SUMMARY:a" + strings.Repeat("жё🎉", 40)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded := foldLine(tt.input)
+			if !strings.HasSuffix(folded, "\r\n") {
+				t.Fatalf("foldLine(%q) = %q, want CRLF at the end", tt.input, folded)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n")
+			var unfolded strings.Builder
+			for i, line := range lines {
+				if len(line) > maxLineOctets {
+					t.Errorf("line %d is %d octets: %q", i, len(line), line)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a character: %q", i, line)
+				}
+				if i > 0 {
+					if !strings.HasPrefix(line, " ") {
+						t.Fatalf("continuation line %d does not start with a space: %q", i, line)
+					}
+					line = line[1:]
+				}
+				unfolded.WriteString(line)
+			}
+			if unfolded.String() != tt.input {
+				t.Errorf("unfolded = %q, want %q", unfolded.String(), tt.input)
+			}
+			if len(tt.input) <= maxLineOctets && len(lines) != 1 {
+				t.Errorf("foldLine(%q) folded a short line into %d lines", tt.input, len(lines))
+			}
+		})
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Встреча", "Встреча"},
+		{"a;b", `a\;b`},
+		{"a,b", `a\,b`},
+		{"строка 1\nстрока 2", `строка 1\nстрока 2`},
+		{"windows\r\nline", `windows\nline`},
+		{`C:\путь`, `C:\\путь`},
+		{"ул. Ленина, д. 5; кв. 3", `ул. Ленина\, д. 5\; кв. 3`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := escapeText(tt.input)
+			if got != tt.want {
+				t.Errorf("escapeText(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if strings.ContainsAny(got, "\r\n") {
+				t.Errorf("escapeText(%q) = %q contains a line break", tt.input, got)
+			}
+
+			want := strings.ReplaceAll(tt.input, "\r\n", "\n")
+			if back := unescapeText(got); back != want {
+				t.Errorf("unescapeText(%q) = %q, want %q", got, back, want)
+			}
+		})
+	}
+}
+
+func TestTimezones(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := func(id int64, start time.Time, tz string) *models.Event {
+		return &models.Event{ID: id, Title: "Встреча", EventDate: start.UTC(), Timezone: tz}
+	}
+
+	tests := []struct {
+		name     string
+		events   []*models.Event
+		want     []string // строки, которые должны быть в календаре
+		wantNot  []string
+		tzBlocks int
+	}{
+		{
+			name:   "berlin with both transitions",
+			events: []*models.Event{event(1, time.Date(2026, 10, 14, 19, 0, 0, 0, berlin), "Europe/Berlin")},
+			want: []string{
+				"TZID:Europe/Berlin\r\n",
+				"DTSTART;TZID=Europe/Berlin:20261014T190000\r\n",
+				"BEGIN:DAYLIGHT\r\nDTSTART:20260329T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\nTZNAME:CEST\r\nEND:DAYLIGHT\r\n",
+				"BEGIN:STANDARD\r\nDTSTART:20261025T030000\r\nTZOFFSETFROM:+0200\r\nTZOFFSETTO:+0100\r\nTZNAME:CET\r\nEND:STANDARD\r\n",
+			},
+			tzBlocks: 1,
+		},
+		{
+			name: "one block per zone",
+			events: []*models.Event{
+				event(1, time.Date(2026, 10, 14, 19, 0, 0, 0, berlin), "Europe/Berlin"),
+				event(2, time.Date(2027, 6, 1, 19, 0, 0, 0, berlin), "Europe/Berlin"),
+			},
+			want:     []string{"DTSTART;TZID=Europe/Berlin:20270601T190000\r\n"},
+			tzBlocks: 1,
+		},
+		{
+			name:     "fixed offset",
+			events:   []*models.Event{event(1, time.Date(2026, 10, 14, 19, 0, 0, 0, time.FixedZone("UTC+03:00", 3*3600)), "UTC+03:00")},
+			want:     []string{"TZID:UTC+03:00\r\n", "TZOFFSETFROM:+0300\r\nTZOFFSETTO:+0300\r\n"},
+			wantNot:  []string{"BEGIN:DAYLIGHT"},
+			tzBlocks: 1,
+		},
+		{
+			name:     "utc needs no VTIMEZONE",
+			events:   []*models.Event{event(1, time.Date(2026, 10, 14, 16, 0, 0, 0, time.UTC), "UTC")},
+			want:     []string{"DTSTART:20261014T160000Z\r\n"},
+			tzBlocks: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := NewCalendar("", time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+			for _, e := range tt.events {
+				cal.Add(e, nil)
+			}
+			text := string(cal.Bytes())
+
+			for _, s := range tt.want {
+				if !strings.Contains(text, s) {
+					t.Errorf("calendar does not contain %q:\n%s", s, text)
+				}
+			}
+			for _, s := range tt.wantNot {
+				if strings.Contains(text, s) {
+					t.Errorf("calendar contains %q:\n%s", s, text)
+				}
+			}
+			if n := strings.Count(text, "BEGIN:VTIMEZONE"); n != tt.tzBlocks {
+				t.Errorf("calendar has %d VTIMEZONE blocks, want %d", n, tt.tzBlocks)
+			}
+
+			// Время в поясе из VTIMEZONE читается обратно в тот же момент
+			items, err := Parse(strings.NewReader(text), time.UTC)
+			if err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+			for i, item := range items {
+				if item.Err != nil || !item.Event.Event.EventDate.Equal(tt.events[i].EventDate) {
+					t.Errorf("event %d parsed as %v (error %v), want %v", i, item.Event.Event.EventDate, item.Err, tt.events[i].EventDate)
+				}
+			}
+		})
+	}
+}
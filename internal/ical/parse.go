@@ -0,0 +1,347 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-planner-bot/internal/models"
+	"event-planner-bot/internal/recurrence"
+)
+
+// Файл не похож на календарь
+var ErrNotCalendar error = &models.ImportError{Reason: models.ImportNotCalendar}
+
+// Мероприятие, прочитанное из календаря. Err - почему его нельзя
+// импортировать; остальные поля при этом заполнены, насколько удалось
+type Item struct {
+	Line  int // строка файла, с которой начинается VEVENT
+	Event models.ImportedEvent
+	Err   error
+}
+
+// Свойство календаря: "DTSTART;TZID=Europe/Moscow:20261014T190000"
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Строка содержимого после склейки перенесённых строк
+type contentLine struct {
+	num  int
+	text string
+}
+
+// Чтение мероприятий (VEVENT) из календаря. Время без часового пояса
+// и мероприятия на весь день относятся к поясу loc
+func Parse(r io.Reader, loc *time.Location) ([]Item, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, &models.ImportError{Reason: models.ImportUnreadable, Value: err.Error()}
+	}
+
+	var items []Item
+	var current []property
+	start := 0
+	inCalendar, inEvent := false, false
+	nested := 0 // вложенные в VEVENT компоненты, например VALARM
+
+	for _, line := range lines {
+		prop, err := parseProperty(line.text)
+		if err != nil {
+			if inEvent {
+				current = append(current, property{name: "X-INVALID", value: line.text})
+			}
+			continue
+		}
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VCALENDAR"):
+			inCalendar = true
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT") && !inEvent:
+			inEvent, current, start, nested = true, nil, line.num, 0
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT") && inEvent && nested == 0:
+			items = append(items, buildItem(start, current, loc))
+			inEvent = false
+		case !inEvent:
+			// Свойства календаря и VTIMEZONE не нужны: пояса берутся по TZID
+		case prop.name == "BEGIN":
+			nested++
+		case prop.name == "END":
+			nested--
+		case nested == 0:
+			current = append(current, prop)
+		}
+	}
+
+	if !inCalendar {
+		return nil, ErrNotCalendar
+	}
+	return items, nil
+}
+
+// Разбиение на строки с обратной склейкой переносов (RFC 5545, 3.1)
+func unfoldLines(r io.Reader) ([]contentLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []contentLine
+	for num := 1; scanner.Scan(); num++ {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if num == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if text == "" {
+			continue
+		}
+		if (text[0] == ' ' || text[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		lines = append(lines, contentLine{num: num, text: text})
+	}
+	return lines, scanner.Err()
+}
+
+// Разбор строки "ИМЯ;ПАРАМЕТР=значение:ЗНАЧЕНИЕ". Двоеточия и точки
+// с запятой внутри кавычек в параметрах не считаются разделителями
+func parseProperty(text string) (property, error) {
+	quoted := false
+	colon := -1
+	for i := 0; i < len(text) && colon < 0; i++ {
+		switch text[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return property{}, &models.ImportError{Reason: models.ImportBadLine, Value: text}
+	}
+
+	head := splitOutsideQuotes(text[:colon], ';')
+	prop := property{
+		name:   strings.ToUpper(head[0]),
+		params: make(map[string]string),
+		value:  text[colon+1:],
+	}
+	for _, param := range head[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return prop, nil
+}
+
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	quoted := false
+	last := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '"':
+			quoted = !quoted
+		case s[i] == sep && !quoted:
+			parts = append(parts, s[last:i])
+			last = i + 1
+		}
+	}
+	return append(parts, s[last:])
+}
+
+// Мероприятие из свойств одного VEVENT
+func buildItem(line int, props []property, loc *time.Location) Item {
+	item := Item{Line: line}
+	event := &item.Event.Event
+	event.Status = models.StatusPlanned
+
+	var dtstart, dtend, duration *property
+	for i := range props {
+		prop := &props[i]
+		switch prop.name {
+		case "SUMMARY":
+			event.Title = strings.TrimSpace(unescapeText(prop.value))
+		case "DESCRIPTION":
+			event.Description = strings.TrimSpace(unescapeText(prop.value))
+		case "LOCATION":
+			event.Location = strings.TrimSpace(unescapeText(prop.value))
+		case "DTSTART":
+			dtstart = prop
+		case "DTEND":
+			dtend = prop
+		case "DURATION":
+			duration = prop
+		case "RRULE":
+			event.Recurrence = prop.value
+		case "STATUS":
+			if strings.EqualFold(prop.value, "CANCELLED") {
+				event.Status = models.StatusCancelled
+			}
+		case "RECURRENCE-ID":
+			item.Err = &models.ImportError{Reason: models.ImportModifiedOccurrence}
+		case "X-INVALID":
+			item.Err = &models.ImportError{Reason: models.ImportBadLine, Value: prop.value}
+		}
+	}
+	if item.Err != nil {
+		return item
+	}
+
+	if event.Title == "" {
+		item.Err = &models.ImportError{Reason: models.ImportNoSummary}
+		return item
+	}
+	if event.Status == models.StatusCancelled {
+		item.Err = &models.ImportError{Reason: models.ImportCancelled}
+		return item
+	}
+	if dtstart == nil {
+		item.Err = &models.ImportError{Reason: models.ImportNoStart}
+		return item
+	}
+
+	start, allDay, eventLoc, err := parseDateTime(dtstart, loc)
+	if err != nil {
+		item.Err = err
+		return item
+	}
+	event.EventDate = start.UTC()
+	event.AllDay = allDay
+	event.Timezone = eventLoc.String()
+
+	switch {
+	case dtend != nil:
+		end, _, _, err := parseDateTime(dtend, loc)
+		if err != nil {
+			item.Err = err
+			return item
+		}
+		event.EndDate = end.UTC()
+	case duration != nil:
+		d, err := parseDuration(duration.value)
+		if err != nil {
+			item.Err = err
+			return item
+		}
+		event.EndDate = start.Add(d).UTC()
+	}
+	if !event.EndDate.IsZero() && !event.EndDate.After(event.EventDate) {
+		item.Err = &models.ImportError{Reason: models.ImportEndBeforeStart}
+		return item
+	}
+
+	if event.Recurrence != "" {
+		rule, err := recurrence.Parse(event.Recurrence)
+		if err != nil {
+			item.Err = &models.ImportError{Reason: models.ImportBadRecurrence, Value: err.Error()}
+			return item
+		}
+		event.Recurrence = rule.String()
+
+		for i := range props {
+			if props[i].name != "EXDATE" {
+				continue
+			}
+			for _, value := range strings.Split(props[i].value, ",") {
+				exdate := props[i]
+				exdate.value = value
+				t, _, _, err := parseDateTime(&exdate, eventLoc)
+				if err != nil {
+					item.Err = err
+					return item
+				}
+				if allDay {
+					// Повторения на весь день начинаются в полночь пояса мероприятия
+					t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, eventLoc)
+				}
+				item.Event.Exceptions = append(item.Event.Exceptions, t.UTC())
+			}
+		}
+	}
+	return item
+}
+
+// Разбор значения даты или даты-времени. Возвращает момент, признак
+// «только дата» и пояс, в котором задано время
+func parseDateTime(prop *property, loc *time.Location) (time.Time, bool, *time.Location, error) {
+	value := strings.TrimSpace(prop.value)
+
+	if tzid := prop.params["TZID"]; tzid != "" {
+		tz, err := models.ParseTimezone(strings.TrimPrefix(tzid, "/"))
+		if err != nil {
+			return time.Time{}, false, nil, &models.ImportError{Reason: models.ImportBadTimezone, Value: tzid}
+		}
+		loc = tz
+	}
+
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		if err != nil {
+			return time.Time{}, false, nil, &models.ImportError{Reason: models.ImportBadDate, Value: value}
+		}
+		return t, true, loc, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, false, nil, &models.ImportError{Reason: models.ImportBadTime, Value: value}
+		}
+		return t, false, time.UTC, nil
+	}
+
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	if err != nil {
+		return time.Time{}, false, nil, &models.ImportError{Reason: models.ImportBadTime, Value: value}
+	}
+	return t, false, loc, nil
+}
+
+var durationPattern = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// Разбор DURATION: "PT1H30M", "P1D", "P2W"
+func parseDuration(value string) (time.Duration, error) {
+	m := durationPattern.FindStringSubmatch(strings.TrimPrefix(value, "+"))
+	if m == nil {
+		return 0, &models.ImportError{Reason: models.ImportBadDuration, Value: value}
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+1] == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(m[i+1])
+		d += time.Duration(n) * unit
+	}
+	if d <= 0 {
+		return 0, &models.ImportError{Reason: models.ImportBadDuration, Value: value}
+	}
+	return d, nil
+}
+
+// Обратное к escapeText
+func unescapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
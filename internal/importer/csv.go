@@ -0,0 +1,206 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-planner-bot/internal/models"
+	"event-planner-bot/internal/recurrence"
+)
+
+// Колонки CSV. Первая строка файла - заголовок с названиями колонок
+const (
+	columnTitle       = "title"
+	columnDescription = "description"
+	columnDate        = "date"
+	columnTime        = "time"
+	columnEndTime     = "end_time"
+	columnLocation    = "location"
+	columnCapacity    = "capacity"
+	columnTimezone    = "timezone"
+	columnRecurrence  = "recurrence"
+)
+
+// Названия колонок в заголовке, по-английски и по-русски
+var columnNames = map[string]string{
+	"title": columnTitle, "name": columnTitle, "summary": columnTitle, "название": columnTitle,
+	"description": columnDescription, "описание": columnDescription,
+	"date": columnDate, "start_date": columnDate, "дата": columnDate,
+	"time": columnTime, "start_time": columnTime, "start": columnTime, "время": columnTime, "начало": columnTime,
+	"end_time": columnEndTime, "end": columnEndTime, "окончание": columnEndTime, "конец": columnEndTime,
+	"location": columnLocation, "place": columnLocation, "место": columnLocation,
+	"capacity": columnCapacity, "мест": columnCapacity, "места": columnCapacity,
+	"timezone": columnTimezone, "tz": columnTimezone, "часовой пояс": columnTimezone, "пояс": columnTimezone,
+	"recurrence": columnRecurrence, "rrule": columnRecurrence, "повтор": columnRecurrence,
+}
+
+var (
+	dateLayouts = []string{"2006-01-02", "02.01.2006", "2.1.2006", "02.01.06"}
+	timeLayouts = []string{"15:04", "15:04:05", "15.04"}
+)
+
+// Чтение CSV с заголовком. Разделитель - запятая или точка с запятой
+// (её по умолчанию ставит русский Excel)
+func parseCSV(data []byte, loc *time.Location) ([]Row, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = detectDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, &models.ImportError{Reason: models.ImportEmptyFile}
+	}
+	if err != nil {
+		return nil, &models.ImportError{Reason: models.ImportUnreadable, Value: err.Error()}
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		if column, ok := columnNames[strings.ToLower(strings.TrimSpace(name))]; ok {
+			if _, dup := columns[column]; !dup {
+				columns[column] = i
+			}
+		}
+	}
+	for _, required := range []string{columnTitle, columnDate} {
+		if _, ok := columns[required]; !ok {
+			return nil, &models.ImportError{Reason: models.ImportMissingColumn, Value: required}
+		}
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, &models.ImportError{Reason: models.ImportUnreadable, Value: err.Error()}
+		}
+
+		line, _ := reader.FieldPos(0)
+		if isBlank(record) {
+			continue
+		}
+
+		field := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := Row{Line: line}
+		row.Err = fillEvent(&row.Event.Event, field, loc)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// Мероприятие из полей строки. Без времени мероприятие - на весь день;
+// окончание раньше начала означает, что мероприятие заканчивается на следующий день
+func fillEvent(event *models.Event, field func(column string) string, loc *time.Location) error {
+	event.Title = field(columnTitle)
+	event.Description = field(columnDescription)
+	event.Location = field(columnLocation)
+	event.Status = models.StatusPlanned
+	if event.Title == "" {
+		return &models.ImportError{Reason: models.ImportNoTitle}
+	}
+
+	if tz := field(columnTimezone); tz != "" {
+		var err error
+		if loc, err = models.ParseTimezone(tz); err != nil {
+			return &models.ImportError{Reason: models.ImportBadTimezone, Value: tz}
+		}
+	}
+	event.Timezone = loc.String()
+
+	date, ok := parseLayouts(field(columnDate), dateLayouts, loc)
+	if !ok {
+		return &models.ImportError{Reason: models.ImportBadCSVDate, Value: field(columnDate)}
+	}
+
+	startText := field(columnTime)
+	if startText == "" {
+		event.EventDate = date.UTC()
+		event.EndDate = date.AddDate(0, 0, 1).UTC()
+		event.AllDay = true
+	} else {
+		start, err := parseClock(date, startText, loc)
+		if err != nil {
+			return err
+		}
+		event.EventDate = start.UTC()
+
+		if endText := field(columnEndTime); endText != "" {
+			end, err := parseClock(date, endText, loc)
+			if err != nil {
+				return err
+			}
+			if !end.After(start) {
+				end = end.AddDate(0, 0, 1)
+			}
+			event.EndDate = end.UTC()
+		}
+	}
+
+	if text := field(columnCapacity); text != "" && text != "-" {
+		capacity, err := strconv.Atoi(text)
+		if err != nil || capacity < 0 {
+			return &models.ImportError{Reason: models.ImportBadCapacity, Value: text}
+		}
+		event.Capacity = capacity
+	}
+
+	if text := field(columnRecurrence); text != "" && text != "-" {
+		rule, err := recurrence.Parse(text)
+		if err != nil {
+			return &models.ImportError{Reason: models.ImportBadRecurrence, Value: err.Error()}
+		}
+		event.Recurrence = rule.String()
+	}
+	return nil
+}
+
+// Время суток text в день date
+func parseClock(date time.Time, text string, loc *time.Location) (time.Time, error) {
+	clock, ok := parseLayouts(text, timeLayouts, time.UTC)
+	if !ok {
+		return time.Time{}, &models.ImportError{Reason: models.ImportBadCSVTime, Value: text}
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, loc), nil
+}
+
+func parseLayouts(text string, layouts []string, loc *time.Location) (time.Time, bool) {
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, text, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Разделитель по первой строке: точка с запятой, если её там больше, чем запятых
+func detectDelimiter(data []byte) rune {
+	first, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(first, []byte(";")) > bytes.Count(first, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
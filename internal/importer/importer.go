@@ -0,0 +1,51 @@
+// Чтение мероприятий из файлов других программ: iCalendar (.ics) и CSV
+package importer
+
+import (
+	"bytes"
+	"path"
+	"strings"
+	"time"
+
+	"event-planner-bot/internal/ical"
+	"event-planner-bot/internal/models"
+)
+
+// Формат файла не поддерживается
+var ErrUnsupportedFormat error = &models.ImportError{Reason: models.ImportUnsupportedFormat}
+
+// Строка файла: мероприятие или причина, по которой его нельзя импортировать
+type Row struct {
+	Line  int // номер строки в файле
+	Event models.ImportedEvent
+	Err   error
+}
+
+// Чтение мероприятий из файла name. Формат определяется по расширению,
+// а если его нет - по содержимому. Время без пояса относится к loc
+func Parse(name string, data []byte, loc *time.Location) ([]Row, error) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".ics", ".ical", ".ifb", ".icalendar":
+		return parseICS(data, loc)
+	case ".csv", ".txt":
+		return parseCSV(data, loc)
+	case "":
+		if bytes.Contains(data, []byte("BEGIN:VCALENDAR")) {
+			return parseICS(data, loc)
+		}
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+func parseICS(data []byte, loc *time.Location) ([]Row, error) {
+	items, err := ical.Parse(bytes.NewReader(data), loc)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]Row, len(items))
+	for i, item := range items {
+		rows[i] = Row{Line: item.Line, Event: item.Event, Err: item.Err}
+	}
+	return rows, nil
+}
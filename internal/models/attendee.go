@@ -0,0 +1,30 @@
+package models
+
+import ("time")
+
+// Участник мероприятия
+type Attendee struct {
+	EventID int64 `json:"event_id"` // id мероприятия
+	UserID int64 `json:"user_id"` // телеграмм id участника
+	Username string `json:"username"` // имя пользователя в телеграмме
+	Name string `json:"name"` // имя участника
+	Status AttendanceStatus `json:"status"` // пойдёт ли на мероприятие
+	CreatedAt time.Time `json:"created_at"` // когда впервые ответил
+	UpdatedAt time.Time `json:"updated_at"` // когда последний раз менял ответ
+}
+
+// Ответ пользователя на приглашение
+type AttendanceStatus string
+
+const (
+	AttendanceGoing AttendanceStatus = "going"
+	AttendanceMaybe AttendanceStatus = "maybe"
+	AttendanceNotGoing AttendanceStatus = "not_going"
+	AttendanceWaitlist AttendanceStatus = "waitlist" // мест нет, ждёт в очереди
+)
+
+// Результат изменения ответа на мероприятие
+type AttendanceChange struct {
+	Status AttendanceStatus `json:"status"` // итоговый статус пользователя
+	Promoted []int64 `json:"promoted"` // кто переведён из очереди в участники
+}
@@ -7,8 +7,15 @@ type Event struct {
 	Title string `json:"title"`  // название мероприятия
 	Description string `json:"description"`  // описание
 	Location string `json:"location"`  // место проведения
-	EventDate time.Time `json:"event_date"`  // дата проведения
+	EventDate time.Time `json:"event_date"`  // дата и время начала (UTC)
+	EndDate time.Time `json:"end_date"`  // время окончания (UTC)
+	AllDay bool `json:"all_day"`  // мероприятие на весь день, без времени
+	Timezone string `json:"timezone"`  // часовой пояс, в котором мероприятие создано
+	Recurrence string `json:"recurrence"`  // правило повторения RRULE, пусто - не повторяется
+	Capacity int `json:"capacity"`  // количество мест, 0 - без ограничений
+	Status EventStatus `json:"status"`  // состояние мероприятия
 	CreatedBy int64 `json:"created_by"`  // кем создано мероприятие
+	ChatID int64 `json:"chat_id"`  // чат, в котором создано; у групп ID отрицательный
 	CreatedAt time.Time `json:"created_at"`  // когда создано
     UpdatedAt time.Time `json:"updated_at"`  // когда обновлено
 }
@@ -21,3 +28,58 @@ const (
 	StatusEnded EventStatus = "ended"
 	StatusCancelled EventStatus = "cancelled"
 )
+
+// Сколько длится мероприятие, если время окончания не указано
+const DefaultEventDuration = 2 * time.Hour
+
+// Время окончания мероприятия; если не указано - через DefaultEventDuration
+// после начала, для мероприятия на весь день - через сутки
+func (e *Event) EndTime() time.Time {
+	switch {
+	case !e.EndDate.IsZero():
+		return e.EndDate
+	case e.AllDay:
+		return e.EventDate.AddDate(0, 0, 1)
+	default:
+		return e.EventDate.Add(DefaultEventDuration)
+	}
+}
+
+// Повторяющееся мероприятие (серия)
+func (e *Event) IsRecurring() bool {
+	return e.Recurrence != ""
+}
+
+// Поля мероприятия, об изменении которых стоит сообщать участникам
+type EventField string
+
+const (
+	FieldTitle EventField = "title"
+	FieldDate EventField = "date"
+	FieldTime EventField = "time"
+	FieldLocation EventField = "location"
+)
+
+// Какие важные для участников поля отличаются в новой версии мероприятия.
+// Дата и время сравниваются в часовом поясе loc
+func ChangedFields(old, updated *Event, loc *time.Location) []EventField {
+	var fields []EventField
+
+	if old.Title != updated.Title {
+		fields = append(fields, FieldTitle)
+	}
+
+	oldStart, newStart := old.EventDate.In(loc), updated.EventDate.In(loc)
+	if oldStart.Format("2006-01-02") != newStart.Format("2006-01-02") {
+		fields = append(fields, FieldDate)
+	}
+	if oldStart.Format("15:04") != newStart.Format("15:04") {
+		fields = append(fields, FieldTime)
+	}
+
+	if old.Location != updated.Location {
+		fields = append(fields, FieldLocation)
+	}
+
+	return fields
+}
@@ -0,0 +1,49 @@
+package models
+
+import ("time")
+
+// Мероприятие из загруженного файла (.ics или CSV)
+type ImportedEvent struct {
+	Event Event `json:"event"` // мероприятие без id
+	Exceptions []time.Time `json:"exceptions"` // пропущенные повторения серии (EXDATE)
+}
+
+// Причина, по которой файл или мероприятие из него нельзя импортировать
+type ImportReason string
+
+const (
+	ImportUnsupportedFormat ImportReason = "unsupported_format"
+	ImportUnreadable ImportReason = "unreadable"  // файл не читается, Value - подробности
+	ImportNotCalendar ImportReason = "not_calendar"
+	ImportEmptyFile ImportReason = "empty_file"
+	ImportMissingColumn ImportReason = "missing_column"  // Value - имя колонки
+	ImportBadLine ImportReason = "bad_line"  // Value - строка календаря
+	ImportModifiedOccurrence ImportReason = "modified_occurrence"
+	ImportNoTitle ImportReason = "no_title"
+	ImportNoSummary ImportReason = "no_summary"
+	ImportCancelled ImportReason = "cancelled"
+	ImportNoStart ImportReason = "no_start"
+	ImportEndBeforeStart ImportReason = "end_before_start"
+	ImportBadTimezone ImportReason = "bad_timezone"
+	ImportBadDate ImportReason = "bad_date"
+	ImportBadTime ImportReason = "bad_time"
+	ImportBadCSVDate ImportReason = "bad_csv_date"
+	ImportBadCSVTime ImportReason = "bad_csv_time"
+	ImportBadDuration ImportReason = "bad_duration"
+	ImportBadCapacity ImportReason = "bad_capacity"
+	ImportBadRecurrence ImportReason = "bad_recurrence"  // Value - ошибка правила
+)
+
+// Ошибка в загруженном файле. Текст для пользователя бот выбирает
+// по Reason на его языке, Value - неверное значение из файла
+type ImportError struct {
+	Reason ImportReason
+	Value string
+}
+
+func (e *ImportError) Error() string {
+	if e.Value == "" {
+		return "import: " + string(e.Reason)
+	}
+	return "import: " + string(e.Reason) + ": " + e.Value
+}
@@ -0,0 +1,13 @@
+package models
+
+import ("time")
+
+// Состояние диалога пользователя с ботом в конкретном чате
+type Session struct {
+	ChatID int64 `json:"chat_id"` // id чата, где идёт диалог
+	UserID int64 `json:"user_id"` // телеграмм id пользователя
+	Kind string `json:"kind"` // тип диалога
+	Step string `json:"step"` // текущий шаг
+	Data map[string]string `json:"data"` // собранные ответы
+	ExpiresAt time.Time `json:"expires_at"` // когда диалог считается заброшенным
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Личные настройки пользователя
+type UserSettings struct {
+	UserID int64 `json:"user_id"` // телеграмм id пользователя
+	Timezone string `json:"timezone"` // часовой пояс (IANA или UTC+03:00), пусто - по умолчанию
+	Language string `json:"language"` // язык интерфейса (ru, en), пусто - ещё не определён
+	Reminders string `json:"reminders"` // за сколько напоминать: "24h,1h", пусто - по умолчанию, RemindersOff - не напоминать
+	Digest DigestFrequency `json:"digest"` // как часто присылать сводку мероприятий
+	Privacy Privacy `json:"privacy"` // кому показывать имя в списках участников
+	UpdatedAt time.Time `json:"updated_at"` // когда настройки менялись
+}
+
+// Значение Reminders, когда напоминания выключены
+const RemindersOff = "off"
+
+// Как часто присылать сводку предстоящих мероприятий
+type DigestFrequency string
+
+const (
+	DigestOff DigestFrequency = ""
+	DigestDaily DigestFrequency = "daily"
+	DigestWeekly DigestFrequency = "weekly"
+)
+
+// Кому видно имя пользователя в списках участников.
+// Сам пользователь и создатель мероприятия видят его всегда
+type Privacy string
+
+const (
+	PrivacyEveryone Privacy = ""
+	PrivacyAttendees Privacy = "attendees" // только тем, кто тоже ответил на мероприятие
+	PrivacyOrganizer Privacy = "organizer" // только создателю мероприятия
+)
+
+// Сроки напоминаний пользователя. false - пользователь их не менял
+// и действуют сроки по умолчанию; пустой список - напоминания выключены
+func (s *UserSettings) ReminderOffsets() ([]time.Duration, bool) {
+	switch s.Reminders {
+	case "":
+		return nil, false
+	case RemindersOff:
+		return nil, true
+	}
+
+	var offsets []time.Duration
+	for _, part := range strings.Split(s.Reminders, ",") {
+		d, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil || d <= 0 {
+			continue
+		}
+		offsets = append(offsets, d)
+	}
+	return offsets, true
+}
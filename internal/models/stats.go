@@ -0,0 +1,19 @@
+package models
+
+// Статистика для админ-панели
+type Stats struct {
+	Users int `json:"users"` // всего пользователей
+	Admins int `json:"admins"` // из них админов
+	Events int `json:"events"` // всего мероприятий
+	Upcoming int `json:"upcoming"` // предстоящие мероприятия
+	Past int `json:"past"` // прошедшие мероприятия
+	Creators []CreatorStat `json:"creators"` // количество мероприятий по создателям
+}
+
+// Количество мероприятий у одного создателя
+type CreatorStat struct {
+	TelegramID int64 `json:"telegram_id"` // телеграмм id создателя
+	Username string `json:"username"` // имя пользователя в телеграмме
+	Name string `json:"name"` // имя создателя
+	Events int `json:"events"` // сколько мероприятий создал
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Смещение от UTC: "+3", "UTC+3", "GMT-05:30"
+var utcOffsetPattern = regexp.MustCompile(`^(?i:utc|gmt)?\s*([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
+// Разбор часового пояса: имя из базы IANA или смещение от UTC
+func ParseTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "local") {
+		return nil, fmt.Errorf("неизвестный часовой пояс %q", name)
+	}
+
+	if m := utcOffsetPattern.FindStringSubmatch(name); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3])
+		if hours > 14 || minutes >= 60 {
+			return nil, fmt.Errorf("неверное смещение %q", name)
+		}
+
+		offset := hours*3600 + minutes*60
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(fmt.Sprintf("UTC%s%02d:%02d", m[1], hours, minutes), offset), nil
+	}
+
+	return time.LoadLocation(name)
+}
+
+// Часовой пояс мероприятия; если он не указан или неизвестен - fallback
+func (e *Event) TimeLocation(fallback *time.Location) *time.Location {
+	if e.Timezone != "" {
+		if loc, err := ParseTimezone(e.Timezone); err == nil {
+			return loc
+		}
+	}
+	return fallback
+}
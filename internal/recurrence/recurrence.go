@@ -0,0 +1,314 @@
+// Правила повторения мероприятий в духе RRULE из RFC 5545:
+// FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, BYDAY, COUNT, UNTIL
+package recurrence
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// День недели в BYDAY. N - номер дня в месяце для MONTHLY:
+// 1MO - первый понедельник, -1FR - последняя пятница, 0 - каждый
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// Правило повторения
+type Rule struct {
+	Freq     Frequency
+	Interval int          // каждый N-й день/неделю/месяц, не меньше 1
+	ByDay    []WeekdayNum // пусто - в тот же день, что и первое мероприятие
+	Count    int          // сколько всего повторений, 0 - без ограничения
+	Until    time.Time    // последний возможный момент начала, нулевое - без ограничения
+}
+
+// Сколько периодов перебирать, пока не найдётся подходящая дата;
+// защита от правил, которые никогда не срабатывают
+const maxPeriods = 10000
+
+var weekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Разбор правила: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10".
+// Префикс "RRULE:" допускается
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("пустое правило повторения")
+	}
+
+	rule := &Rule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("неверная часть правила %q", part)
+		}
+
+		switch key {
+		case "FREQ":
+			switch f := Frequency(value); f {
+			case Daily, Weekly, Monthly:
+				rule.Freq = f
+			default:
+				return nil, fmt.Errorf("неподдерживаемая частота %q", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("неверный INTERVAL %q", value)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("неверный COUNT %q", value)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, err := parseWeekdayNum(code)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "WKST":
+			// Неделя всегда начинается с понедельника
+		default:
+			return nil, fmt.Errorf("неподдерживаемый параметр %q", key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("не указан FREQ")
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, fmt.Errorf("COUNT и UNTIL нельзя указывать вместе")
+	}
+	for _, day := range rule.ByDay {
+		if day.N != 0 && rule.Freq != Monthly {
+			return nil, fmt.Errorf("номер дня в BYDAY допустим только для MONTHLY")
+		}
+	}
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				// Дата без времени - включительно до конца дня
+				t = t.Add(24*time.Hour - time.Second)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("неверный UNTIL %q", value)
+}
+
+func parseWeekdayNum(code string) (WeekdayNum, error) {
+	if len(code) < 2 {
+		return WeekdayNum{}, fmt.Errorf("неверный день недели %q", code)
+	}
+
+	var day WeekdayNum
+	found := false
+	for i, c := range weekdayCodes {
+		if strings.HasSuffix(code, c) {
+			day.Day = time.Weekday(i)
+			found = true
+			break
+		}
+	}
+	if !found {
+		return WeekdayNum{}, fmt.Errorf("неверный день недели %q", code)
+	}
+
+	if prefix := strings.TrimSuffix(code, weekdayCodes[day.Day]); prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return WeekdayNum{}, fmt.Errorf("неверный день недели %q", code)
+		}
+		day.N = n
+	}
+	return day, nil
+}
+
+// Правило в формате RRULE без префикса
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			codes[i] = weekdayCodes[day.Day]
+			if day.N != 0 {
+				codes[i] = strconv.Itoa(day.N) + codes[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Перебор начал повторений по порядку, начиная с start (первое
+// мероприятие серии). Время суток берётся из start в его часовом поясе,
+// так что переход на летнее время не сдвигает мероприятие.
+// Перебор идёт, пока fn возвращает true и правило не исчерпано
+func (r *Rule) Each(start time.Time, fn func(t time.Time) bool) {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	n := 0
+	for period, empty := 0, 0; empty < maxPeriods; period++ {
+		candidates := r.period(start, period*interval)
+		if len(candidates) == 0 {
+			empty++
+			continue
+		}
+		empty = 0
+
+		for _, t := range candidates {
+			if t.Before(start) {
+				continue
+			}
+			if !r.Until.IsZero() && t.After(r.Until) {
+				return
+			}
+			n++
+			if !fn(t) {
+				return
+			}
+			if r.Count > 0 && n >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// Повторения, которые начинаются в промежутке [from, to)
+func (r *Rule) Between(start, from, to time.Time) []time.Time {
+	var result []time.Time
+	r.Each(start, func(t time.Time) bool {
+		if !t.Before(to) {
+			return false
+		}
+		if !t.Before(from) {
+			result = append(result, t)
+		}
+		return true
+	})
+	return result
+}
+
+// Даты-кандидаты в периоде со смещением offset (в днях, неделях или месяцах)
+func (r *Rule) period(start time.Time, offset int) []time.Time {
+	loc := start.Location()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), loc)
+	}
+
+	switch r.Freq {
+	case Daily:
+		t := at(start.Year(), start.Month(), start.Day()+offset)
+		if len(r.ByDay) > 0 && !r.hasWeekday(t.Weekday()) {
+			return nil
+		}
+		return []time.Time{t}
+
+	case Weekly:
+		// Понедельник недели, в которую попадает start
+		monday := start.Day() - (int(start.Weekday())+6)%7 + offset*7
+		if len(r.ByDay) == 0 {
+			return []time.Time{at(start.Year(), start.Month(), monday+(int(start.Weekday())+6)%7)}
+		}
+
+		var result []time.Time
+		for _, day := range r.ByDay {
+			result = append(result, at(start.Year(), start.Month(), monday+(int(day.Day)+6)%7))
+		}
+		sortTimes(result)
+		return result
+
+	case Monthly:
+		first := time.Date(start.Year(), start.Month()+time.Month(offset), 1, 0, 0, 0, 0, loc)
+		year, month := first.Year(), first.Month()
+		days := daysIn(year, month, loc)
+
+		if len(r.ByDay) == 0 {
+			if start.Day() > days {
+				// 31-е есть не в каждом месяце - такие месяцы пропускаются
+				return nil
+			}
+			return []time.Time{at(year, month, start.Day())}
+		}
+
+		var result []time.Time
+		for _, day := range r.ByDay {
+			// Первый такой день недели в месяце
+			firstDay := 1 + (int(day.Day)-int(first.Weekday())+7)%7
+			switch {
+			case day.N == 0:
+				for d := firstDay; d <= days; d += 7 {
+					result = append(result, at(year, month, d))
+				}
+			case day.N > 0:
+				if d := firstDay + (day.N-1)*7; d <= days {
+					result = append(result, at(year, month, d))
+				}
+			default:
+				last := firstDay + (days-firstDay)/7*7
+				if d := last + (day.N+1)*7; d >= 1 {
+					result = append(result, at(year, month, d))
+				}
+			}
+		}
+		sortTimes(result)
+		return result
+	}
+	return nil
+}
+
+func (r *Rule) hasWeekday(wd time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day.Day == wd {
+			return true
+		}
+	}
+	return false
+}
+
+func daysIn(year int, month time.Month, loc *time.Location) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+}
+
+func sortTimes(times []time.Time) {
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+}
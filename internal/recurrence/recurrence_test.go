@@ -0,0 +1,161 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEach(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Летнее время в Берлине в 2026 году: с 29 марта по 25 октября
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		limit int // сколько повторений перебрать для правил без ограничения
+		want  []time.Time
+	}{
+		{
+			name:  "daily across spring DST",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: at(3, 28, 10, 0),
+			want:  []time.Time{at(3, 28, 10, 0), at(3, 29, 10, 0), at(3, 30, 10, 0)},
+		},
+		{
+			name:  "daily across autumn DST",
+			rule:  "FREQ=DAILY;INTERVAL=2",
+			start: at(10, 23, 19, 30),
+			limit: 3,
+			want:  []time.Time{at(10, 23, 19, 30), at(10, 25, 19, 30), at(10, 27, 19, 30)},
+		},
+		{
+			name:  "weekly BYDAY with COUNT across DST",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4",
+			start: at(3, 25, 19, 0),
+			want:  []time.Time{at(3, 25, 19, 0), at(3, 30, 19, 0), at(4, 1, 19, 0), at(4, 6, 19, 0)},
+		},
+		{
+			name:  "weekly BYDAY skips days before start",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=3",
+			start: at(10, 14, 18, 0),
+			want:  []time.Time{at(10, 16, 18, 0), at(10, 19, 18, 0), at(10, 23, 18, 0)},
+		},
+		{
+			name:  "every other week",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+			start: at(3, 23, 9, 0),
+			want:  []time.Time{at(3, 23, 9, 0), at(4, 6, 9, 0), at(4, 20, 9, 0)},
+		},
+		{
+			name:  "UNTIL date includes the whole day",
+			rule:  "FREQ=DAILY;UNTIL=20261025",
+			start: at(10, 23, 10, 0),
+			want:  []time.Time{at(10, 23, 10, 0), at(10, 24, 10, 0), at(10, 25, 10, 0)},
+		},
+		{
+			name:  "UNTIL time in UTC after DST ends",
+			rule:  "FREQ=DAILY;UNTIL=20261026T085959Z",
+			start: at(10, 24, 10, 0),
+			want:  []time.Time{at(10, 24, 10, 0), at(10, 25, 10, 0)},
+		},
+		{
+			name:  "weekly BYDAY with UNTIL",
+			rule:  "FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=20261103T170000Z",
+			start: at(10, 20, 18, 0),
+			want:  []time.Time{at(10, 20, 18, 0), at(10, 22, 18, 0), at(10, 27, 18, 0), at(10, 29, 18, 0), at(11, 3, 18, 0)},
+		},
+		{
+			name:  "last friday of the month",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR;COUNT=4",
+			start: at(1, 30, 18, 0),
+			want:  []time.Time{at(1, 30, 18, 0), at(2, 27, 18, 0), at(3, 27, 18, 0), at(4, 24, 18, 0)},
+		},
+		{
+			name:  "second monday of the month",
+			rule:  "FREQ=MONTHLY;BYDAY=2MO;COUNT=3",
+			start: at(9, 14, 12, 0),
+			want:  []time.Time{at(9, 14, 12, 0), at(10, 12, 12, 0), at(11, 9, 12, 0)},
+		},
+		{
+			name:  "monthly on the 31st skips short months",
+			rule:  "FREQ=MONTHLY;COUNT=3",
+			start: at(1, 31, 8, 0),
+			want:  []time.Time{at(1, 31, 8, 0), at(3, 31, 8, 0), at(5, 31, 8, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.rule, err)
+			}
+
+			var got []time.Time
+			rule.Each(tt.start, func(t time.Time) bool {
+				got = append(got, t)
+				return tt.limit == 0 || len(got) < tt.limit
+			})
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Each(%q) = %v, want %v", tt.rule, got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Each(%q) occurrence %d = %v, want %v", tt.rule, i, got[i], tt.want[i])
+				}
+				if got[i].Location() != loc {
+					t.Errorf("Each(%q) occurrence %d in %v, want %v", tt.rule, i, got[i].Location(), loc)
+				}
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, loc)
+	}
+
+	rule, err := Parse("FREQ=WEEKLY;BYDAY=MO,TH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := at(10, 19, 19)
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     []time.Time
+	}{
+		{"from start", start, at(10, 27, 0), []time.Time{at(10, 19, 19), at(10, 22, 19), at(10, 26, 19)}},
+		{"to is exclusive", at(10, 20, 0), at(10, 26, 19), []time.Time{at(10, 22, 19)}},
+		{"before start", at(10, 1, 0), at(10, 20, 0), []time.Time{at(10, 19, 19)}},
+		{"empty range", at(10, 23, 0), at(10, 26, 0), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rule.Between(start, tt.from, tt.to)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Between(%v, %v) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Between(%v, %v) occurrence %d = %v, want %v", tt.from, tt.to, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
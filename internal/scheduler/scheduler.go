@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Периодическая фоновая задача
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(now time.Time)
+}
+
+// Планировщик фоновых задач
+type Scheduler struct {
+	jobs []Job
+	wg   sync.WaitGroup
+}
+
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Регистрация задачи; вызывать до Start
+func (s *Scheduler) Every(name string, interval time.Duration, run func(now time.Time)) {
+	s.jobs = append(s.jobs, Job{Name: name, Interval: interval, Run: run})
+}
+
+// Запуск всех задач. Каждая задача выполняется сразу и затем с заданным интервалом,
+// пока не отменён контекст
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+}
+
+// Ожидание завершения задач после отмены контекста
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	log.Printf("Задача %q запущена, интервал %s", job.Name, job.Interval)
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(job)
+
+		select {
+		case <-ctx.Done():
+			log.Printf("Задача %q остановлена", job.Name)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Выполнение задачи; паника в задаче не останавливает планировщик
+func (s *Scheduler) run(job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Паника в задаче %q: %v", job.Name, r)
+		}
+	}()
+
+	job.Run(time.Now())
+}
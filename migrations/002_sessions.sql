@@ -0,0 +1,10 @@
+-- Создание таблицы незавершённых диалогов с ботом
+CREATE TABLE IF NOT EXISTS sessions (
+    chat_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    step TEXT NOT NULL,
+    data TEXT NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (chat_id, user_id)
+);
@@ -0,0 +1,14 @@
+-- Создание таблицы участников мероприятий
+CREATE TABLE IF NOT EXISTS attendees (
+    event_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    status TEXT NOT NULL,  -- going, maybe, not_going, waitlist
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id, user_id),
+    FOREIGN KEY (event_id) REFERENCES events(id),
+    FOREIGN KEY (user_id) REFERENCES users(telegram_id)
+);
+
+-- Создание индекса для поиска мероприятий пользователя
+CREATE INDEX IF NOT EXISTS idx_attendees_user ON attendees(user_id);
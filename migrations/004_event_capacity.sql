@@ -0,0 +1,2 @@
+-- Количество мест на мероприятии, 0 - без ограничений
+ALTER TABLE events ADD COLUMN capacity INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,8 @@
+-- Создание таблицы отправленных напоминаний
+CREATE TABLE IF NOT EXISTS sent_reminders (
+    event_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    offset_minutes INTEGER NOT NULL,  -- за сколько минут до начала
+    sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id, user_id, offset_minutes)
+);
@@ -0,0 +1,2 @@
+-- Состояние мероприятия: planned, ongoing, ended, cancelled
+ALTER TABLE events ADD COLUMN status TEXT NOT NULL DEFAULT 'planned';
@@ -0,0 +1,13 @@
+-- Время окончания, мероприятия на весь день и часовой пояс мероприятия
+ALTER TABLE events ADD COLUMN end_date TIMESTAMP;
+ALTER TABLE events ADD COLUMN all_day BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE events ADD COLUMN timezone TEXT NOT NULL DEFAULT '';
+-- end_date у старых мероприятий бот заполняет при запуске (начало + 2 часа)
+
+-- Создание таблицы личных настроек пользователей
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id INTEGER PRIMARY KEY,
+    timezone TEXT NOT NULL DEFAULT '',  -- пусто - часовой пояс по умолчанию
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(telegram_id)
+);
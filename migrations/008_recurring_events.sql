@@ -0,0 +1,27 @@
+-- Правило повторения мероприятия (RRULE), пусто - не повторяется
+ALTER TABLE events ADD COLUMN recurrence TEXT NOT NULL DEFAULT '';
+
+-- Пропущенные и отделённые повторения серий
+CREATE TABLE IF NOT EXISTS event_exceptions (
+    event_id INTEGER NOT NULL,
+    occurrence_date TIMESTAMP NOT NULL,  -- начало повторения (UTC)
+    PRIMARY KEY (event_id, occurrence_date),
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+
+-- Напоминания отмечаются для каждого повторения отдельно.
+-- Первичный ключ меняется, поэтому таблица пересоздаётся
+CREATE TABLE sent_reminders_new (
+    event_id INTEGER NOT NULL,
+    occurrence INTEGER NOT NULL DEFAULT 0,  -- начало мероприятия или повторения, Unix-время
+    user_id INTEGER NOT NULL,
+    offset_minutes INTEGER NOT NULL,
+    sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id, occurrence, user_id, offset_minutes)
+);
+INSERT INTO sent_reminders_new (event_id, occurrence, user_id, offset_minutes, sent_at)
+SELECT r.event_id, CAST(strftime('%s', e.date) AS INTEGER), r.user_id, r.offset_minutes, r.sent_at
+FROM sent_reminders r
+JOIN events e ON e.id = r.event_id;
+DROP TABLE sent_reminders;
+ALTER TABLE sent_reminders_new RENAME TO sent_reminders;
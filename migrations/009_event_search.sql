@@ -0,0 +1,34 @@
+-- Полнотекстовый поиск по мероприятиям (нужна сборка с тегом sqlite_fts5).
+-- «ё» в индексе заменяется на «е», регистр снимает токенизатор unicode61
+CREATE VIRTUAL TABLE IF NOT EXISTS events_fts USING fts5(
+    title, description, location,
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+INSERT INTO events_fts (rowid, title, description, location)
+SELECT id,
+       replace(replace(coalesce(title, ''), 'ё', 'е'), 'Ё', 'Е'),
+       replace(replace(coalesce(description, ''), 'ё', 'е'), 'Ё', 'Е'),
+       replace(replace(coalesce(location, ''), 'ё', 'е'), 'Ё', 'Е')
+FROM events;
+
+CREATE TRIGGER events_fts_insert AFTER INSERT ON events BEGIN
+    INSERT INTO events_fts (rowid, title, description, location) VALUES (
+        new.id,
+        replace(replace(coalesce(new.title, ''), 'ё', 'е'), 'Ё', 'Е'),
+        replace(replace(coalesce(new.description, ''), 'ё', 'е'), 'Ё', 'Е'),
+        replace(replace(coalesce(new.location, ''), 'ё', 'е'), 'Ё', 'Е'));
+END;
+
+CREATE TRIGGER events_fts_update AFTER UPDATE OF title, description, location ON events BEGIN
+    DELETE FROM events_fts WHERE rowid = old.id;
+    INSERT INTO events_fts (rowid, title, description, location) VALUES (
+        new.id,
+        replace(replace(coalesce(new.title, ''), 'ё', 'е'), 'Ё', 'Е'),
+        replace(replace(coalesce(new.description, ''), 'ё', 'е'), 'Ё', 'Е'),
+        replace(replace(coalesce(new.location, ''), 'ё', 'е'), 'Ё', 'Е'));
+END;
+
+CREATE TRIGGER events_fts_delete AFTER DELETE ON events BEGIN
+    DELETE FROM events_fts WHERE rowid = old.id;
+END;
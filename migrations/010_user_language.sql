@@ -0,0 +1,2 @@
+-- Язык интерфейса пользователя: ru, en; пусто - бот возьмёт язык из Telegram
+ALTER TABLE user_settings ADD COLUMN language TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,15 @@
+-- Напоминания, сводка и приватность в настройках пользователя.
+-- reminders: сроки через запятую ("24h,1h"), пусто - по умолчанию, "off" - не напоминать
+ALTER TABLE user_settings ADD COLUMN reminders TEXT NOT NULL DEFAULT '';
+-- digest: "", "daily" или "weekly"
+ALTER TABLE user_settings ADD COLUMN digest TEXT NOT NULL DEFAULT '';
+-- privacy: кому видно имя в списках участников: "" - всем, "attendees", "organizer"
+ALTER TABLE user_settings ADD COLUMN privacy TEXT NOT NULL DEFAULT '';
+
+-- Отправленные сводки, чтобы не слать их повторно после перезапуска
+CREATE TABLE IF NOT EXISTS sent_digests (
+    user_id INTEGER NOT NULL,
+    period TEXT NOT NULL,  -- день «2026-12-31» или неделя «2026-W53»
+    sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, period)
+);
@@ -0,0 +1,3 @@
+-- Чат, в котором создано мероприятие: в группе видны только её мероприятия.
+-- У групп ID отрицательный, 0 - мероприятия, созданные до поддержки групп
+ALTER TABLE events ADD COLUMN chat_id INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Отделённые повторения серий: ID мероприятия, в которое превратилось
+-- повторение, 0 - повторение просто пропущено
+ALTER TABLE event_exceptions ADD COLUMN detached_id INTEGER NOT NULL DEFAULT 0;
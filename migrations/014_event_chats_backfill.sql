@@ -0,0 +1,3 @@
+-- Мероприятия, созданные до поддержки групп, относятся к личному чату
+-- создателя: ID личного чата совпадает с ID пользователя
+UPDATE events SET chat_id = created_by WHERE chat_id = 0;